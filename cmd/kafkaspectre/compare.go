@@ -0,0 +1,517 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ppiankov/kafkaspectre/internal/config"
+	"github.com/ppiankov/kafkaspectre/internal/kafka"
+	"github.com/ppiankov/kafkaspectre/internal/reporter"
+	"github.com/spf13/cobra"
+)
+
+// clusterAuth holds the SASL and TLS settings used to connect to one
+// cluster.
+type clusterAuth struct {
+	authMechanism string
+	username      string
+	password      string
+	tlsEnabled    bool
+	tlsCert       string
+	tlsKey        string
+	tlsCA         string
+}
+
+type compareOptions struct {
+	source      string
+	target      string
+	sourceAlias string
+	// auth applies to both clusters; targetAuth overrides it for the
+	// target, which in a DR pair usually has its own credentials and CA.
+	auth            clusterAuth
+	targetAuth      clusterAuth
+	output          string
+	excludeInternal bool
+	excludeTopics   []string
+	timeout         time.Duration
+}
+
+func newCompareCmd() *cobra.Command {
+	var opts compareOptions
+
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare topics, configs and consumer offsets between two Kafka clusters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolved, err := resolveCompareOptions(cmd, opts)
+			if err != nil {
+				return err
+			}
+			return runCompare(cmd, resolved)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.source, "source", "", "Source cluster bootstrap server(s) (host:port, comma-separated)")
+	flags.StringVar(&opts.target, "target", "", "Target cluster bootstrap server(s) (host:port, comma-separated)")
+	flags.StringVar(&opts.sourceAlias, "source-alias", "", "MirrorMaker 2 source cluster alias; target topics are expected as <alias>.<topic>")
+	flags.StringVar(&opts.auth.authMechanism, "auth-mechanism", "", "SASL mechanism (PLAIN, SCRAM-SHA-256, SCRAM-SHA-512)")
+	flags.StringVar(&opts.auth.username, "username", "", "SASL username")
+	flags.StringVar(&opts.auth.password, "password", "", "SASL password")
+	flags.BoolVar(&opts.auth.tlsEnabled, "tls", false, "Enable TLS")
+	flags.StringVar(&opts.auth.tlsCert, "tls-cert", "", "Path to TLS client certificate")
+	flags.StringVar(&opts.auth.tlsKey, "tls-key", "", "Path to TLS client private key")
+	flags.StringVar(&opts.auth.tlsCA, "tls-ca", "", "Path to TLS CA certificate")
+	flags.StringVar(&opts.targetAuth.authMechanism, "target-auth-mechanism", "", "SASL mechanism for the target cluster (defaults to --auth-mechanism)")
+	flags.StringVar(&opts.targetAuth.username, "target-username", "", "SASL username for the target cluster (defaults to --username)")
+	flags.StringVar(&opts.targetAuth.password, "target-password", "", "SASL password for the target cluster (defaults to --password)")
+	flags.BoolVar(&opts.targetAuth.tlsEnabled, "target-tls", false, "Enable TLS for the target cluster (defaults to --tls)")
+	flags.StringVar(&opts.targetAuth.tlsCert, "target-tls-cert", "", "Path to TLS client certificate for the target cluster (defaults to --tls-cert)")
+	flags.StringVar(&opts.targetAuth.tlsKey, "target-tls-key", "", "Path to TLS client private key for the target cluster (defaults to --tls-key)")
+	flags.StringVar(&opts.targetAuth.tlsCA, "target-tls-ca", "", "Path to TLS CA certificate for the target cluster (defaults to --tls-ca)")
+	flags.StringVar(&opts.output, "output", "text", "Output format (json|sarif|text)")
+	flags.BoolVar(&opts.excludeInternal, "exclude-internal", false, "Exclude internal topics from analysis")
+	flags.StringSliceVar(&opts.excludeTopics, "exclude-topics", nil, "Exclude topics by name or glob pattern (repeatable)")
+	flags.DurationVar(&opts.timeout, "timeout", 0, "Kafka query timeout (for example: 10s, 1m)")
+
+	return cmd
+}
+
+func resolveCompareOptions(cmd *cobra.Command, opts compareOptions) (compareOptions, error) {
+	cfg, cfgPath, err := config.Load()
+	if err != nil {
+		return opts, err
+	}
+	if cfg != nil {
+		slog.Debug("loaded defaults from config", "path", cfgPath)
+		opts = applyCompareConfigDefaults(cmd, opts, cfg)
+	}
+
+	patterns, err := normalizeExcludePatterns(opts.excludeTopics)
+	if err != nil {
+		return opts, err
+	}
+	opts.excludeTopics = patterns
+
+	if opts.timeout == 0 {
+		opts.timeout = defaultQueryTimeout
+	}
+	opts.targetAuth = resolveTargetAuth(opts.auth, opts.targetAuth, flagChanged(cmd, "target-tls"))
+
+	return opts, nil
+}
+
+// resolveTargetAuth fills target settings that were not given from the
+// shared ones. SASL settings and the client certificate/key pair fall back
+// as a whole, so credentials of the two clusters are never mixed.
+func resolveTargetAuth(shared, target clusterAuth, tlsSet bool) clusterAuth {
+	if target.authMechanism == "" && target.username == "" && target.password == "" {
+		target.authMechanism, target.username, target.password = shared.authMechanism, shared.username, shared.password
+	}
+	if !tlsSet {
+		target.tlsEnabled = shared.tlsEnabled
+	}
+	if target.tlsCert == "" && target.tlsKey == "" {
+		target.tlsCert, target.tlsKey = shared.tlsCert, shared.tlsKey
+	}
+	if target.tlsCA == "" {
+		target.tlsCA = shared.tlsCA
+	}
+	return target
+}
+
+func applyCompareConfigDefaults(cmd *cobra.Command, opts compareOptions, cfg *config.Config) compareOptions {
+	if !flagChanged(cmd, "auth-mechanism") && strings.TrimSpace(opts.auth.authMechanism) == "" && strings.TrimSpace(cfg.AuthMechanism) != "" {
+		opts.auth.authMechanism = cfg.AuthMechanism
+	}
+	if !flagChanged(cmd, "output") && strings.TrimSpace(cfg.Format) != "" {
+		opts.output = cfg.Format
+	}
+	if !flagChanged(cmd, "exclude-internal") && cfg.ExcludeInternal != nil {
+		opts.excludeInternal = *cfg.ExcludeInternal
+	}
+	if !flagChanged(cmd, "exclude-topics") && len(cfg.ExcludeTopics) > 0 {
+		opts.excludeTopics = append([]string(nil), cfg.ExcludeTopics...)
+	}
+	if !flagChanged(cmd, "timeout") && cfg.HasTimeout {
+		opts.timeout = cfg.Timeout
+	}
+
+	return opts
+}
+
+func runCompare(cmd *cobra.Command, opts compareOptions) error {
+	start := time.Now()
+
+	if strings.TrimSpace(opts.source) == "" {
+		return errors.New("source bootstrap server is required")
+	}
+	if strings.TrimSpace(opts.target) == "" {
+		return errors.New("target bootstrap server is required")
+	}
+	excludePatterns, err := normalizeExcludePatterns(opts.excludeTopics)
+	if err != nil {
+		return err
+	}
+
+	output := strings.ToLower(strings.TrimSpace(opts.output))
+	if output == "" {
+		output = "text"
+	}
+	if output != "json" && output != "sarif" && output != "text" {
+		return fmt.Errorf("invalid output format %q (expected json, sarif, or text)", opts.output)
+	}
+	if err := validateClusterAuth(opts.auth, ""); err != nil {
+		return err
+	}
+	if err := validateClusterAuth(opts.targetAuth, "target-"); err != nil {
+		return err
+	}
+	if opts.timeout <= 0 {
+		return errors.New("timeout must be greater than zero")
+	}
+	sourceAlias := strings.TrimSpace(opts.sourceAlias)
+	if strings.Contains(sourceAlias, ".") {
+		return fmt.Errorf("invalid source alias %q (must not contain '.')", opts.sourceAlias)
+	}
+
+	sourceMetadata, err := fetchClusterMetadata(cmd.Context(), opts.auth, opts.source, opts.timeout)
+	if err != nil {
+		return fmt.Errorf("source cluster: %w", err)
+	}
+	targetMetadata, err := fetchClusterMetadata(cmd.Context(), opts.targetAuth, opts.target, opts.timeout)
+	if err != nil {
+		return fmt.Errorf("target cluster: %w", err)
+	}
+
+	result := buildCompareResult(sourceMetadata, targetMetadata, sourceAlias, opts.excludeInternal, excludePatterns)
+	result.Tool = "kafkaspectre"
+	result.Version = Version
+	result.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	result.Summary.SourceCluster = opts.source
+	result.Summary.TargetCluster = opts.target
+
+	if output == "text" {
+		_, err := fmt.Fprintf(cmd.OutOrStdout(), "KafkaSpectre Compare\n")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Source: %s\n", opts.source)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Target: %s\n", opts.target)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "--------------------------------------------------\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	var generateErr error
+	switch output {
+	case "json":
+		compareReporter := reporter.NewCompareJSONReporter(cmd.OutOrStdout(), false)
+		generateErr = compareReporter.GenerateCompare(context.Background(), result)
+	case "sarif":
+		sarifReporter := reporter.NewSARIFReporter(cmd.OutOrStdout(), false)
+		generateErr = sarifReporter.GenerateCompare(context.Background(), result)
+	case "text":
+		compareReporter := reporter.NewCompareTextReporter(cmd.OutOrStdout())
+		generateErr = compareReporter.GenerateCompare(context.Background(), result)
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+
+	if generateErr != nil {
+		return generateErr
+	}
+
+	slog.Info("compare completed",
+		"source_topic_count", len(sourceMetadata.Topics),
+		"target_topic_count", len(targetMetadata.Topics),
+		"duration", time.Since(start),
+	)
+
+	if result.Summary.TotalFindings > 0 {
+		return &FindingsError{Count: result.Summary.TotalFindings}
+	}
+
+	return nil
+}
+
+// validateClusterAuth checks one cluster's settings; prefix is the flag
+// prefix they were given with ("" or "target-").
+func validateClusterAuth(auth clusterAuth, prefix string) error {
+	if auth.authMechanism != "" && (auth.username == "" || auth.password == "") {
+		return fmt.Errorf("%sauth-mechanism requires both --%susername and --%spassword", prefix, prefix, prefix)
+	}
+	if (auth.tlsCert == "") != (auth.tlsKey == "") {
+		return fmt.Errorf("--%stls-cert and --%stls-key must be provided together", prefix, prefix)
+	}
+	return nil
+}
+
+func fetchClusterMetadata(ctx context.Context, auth clusterAuth, bootstrapServer string, timeout time.Duration) (*kafka.ClusterMetadata, error) {
+	kafkaCfg := kafka.Config{
+		BootstrapServers: bootstrapServer,
+		AuthMechanism:    auth.authMechanism,
+		Username:         auth.username,
+		Password:         auth.password,
+		TLSEnabled:       auth.tlsEnabled,
+		TLSCertFile:      auth.tlsCert,
+		TLSKeyFile:       auth.tlsKey,
+		TLSCAFile:        auth.tlsCA,
+		QueryTimeout:     timeout,
+	}
+
+	inspector, err := kafka.NewInspector(kafkaCfg)
+	if err != nil {
+		return nil, err
+	}
+	defer inspector.Close()
+
+	fetchCtx, cancel := context.WithTimeout(ctx, kafkaCfg.QueryTimeout)
+	defer cancel()
+
+	slog.Info("connecting to Kafka", "bootstrap_servers", bootstrapServer)
+
+	return inspector.FetchMetadata(fetchCtx)
+}
+
+// buildCompareResult compares a source cluster with its DR target. When
+// sourceAlias is set, source topic T is expected on the target as <alias>.T
+// (MirrorMaker 2 DefaultReplicationPolicy); otherwise names must match exactly.
+func buildCompareResult(source, target *kafka.ClusterMetadata, sourceAlias string, excludeInternal bool, excludeTopics []string) *reporter.CompareResult {
+	sourceTopics := compareTopicSet(source, excludeInternal, excludeTopics)
+	targetTopics := compareTopicSet(target, excludeInternal, excludeTopics)
+
+	targetName := func(topic string) string {
+		if sourceAlias == "" {
+			return topic
+		}
+		return sourceAlias + "." + topic
+	}
+
+	summary := &reporter.CompareSummary{
+		SourceAlias:          sourceAlias,
+		SourceTopics:         len(sourceTopics),
+		TargetTopics:         len(targetTopics),
+		SourceConsumerGroups: len(source.ConsumerGroups),
+		TargetConsumerGroups: len(target.ConsumerGroups),
+	}
+	findings := make([]*reporter.CompareFinding, 0)
+	matchedTargets := make(map[string]struct{}, len(targetTopics))
+
+	for _, name := range sortedTopicNames(sourceTopics) {
+		sourceTopic := sourceTopics[name]
+		mapped := targetName(name)
+		targetTopic, ok := targetTopics[mapped]
+		if !ok {
+			findings = append(findings, &reporter.CompareFinding{
+				Kind:        reporter.CompareKindMissingOnTarget,
+				Topic:       name,
+				TargetTopic: mapped,
+				Reason:      "topic exists on source cluster but not on target cluster",
+			})
+			continue
+		}
+
+		matchedTargets[mapped] = struct{}{}
+		summary.MatchedTopics++
+
+		if sourceTopic.Partitions != targetTopic.Partitions {
+			findings = append(findings, &reporter.CompareFinding{
+				Kind:        reporter.CompareKindPartitionMismatch,
+				Topic:       name,
+				TargetTopic: mapped,
+				Key:         "partitions",
+				SourceValue: strconv.Itoa(sourceTopic.Partitions),
+				TargetValue: strconv.Itoa(targetTopic.Partitions),
+				Reason:      "partition count differs between clusters",
+			})
+		}
+
+		findings = append(findings, compareTopicConfigs(name, mapped, sourceTopic, targetTopic)...)
+	}
+
+	for _, name := range sortedTopicNames(targetTopics) {
+		if _, ok := matchedTargets[name]; ok {
+			continue
+		}
+		findings = append(findings, &reporter.CompareFinding{
+			Kind:   reporter.CompareKindMissingOnSource,
+			Topic:  name,
+			Reason: "topic exists on target cluster but not on source cluster",
+		})
+	}
+
+	findings = append(findings, compareGroupOffsets(source, target, sourceTopics, targetTopics, targetName)...)
+
+	for _, finding := range findings {
+		switch finding.Kind {
+		case reporter.CompareKindMissingOnTarget:
+			summary.MissingOnTargetCount++
+		case reporter.CompareKindMissingOnSource:
+			summary.MissingOnSourceCount++
+		case reporter.CompareKindPartitionMismatch:
+			summary.PartitionMismatchCount++
+		case reporter.CompareKindConfigMismatch:
+			summary.ConfigMismatchCount++
+		case reporter.CompareKindOffsetGap:
+			summary.OffsetGapCount++
+		}
+	}
+	summary.TotalFindings = len(findings)
+
+	return &reporter.CompareResult{
+		Summary:  summary,
+		Findings: findings,
+	}
+}
+
+// compareTopicSet returns the topics that take part in a parity comparison.
+// MirrorMaker 2 bookkeeping topics (heartbeats, checkpoints, offset-syncs,
+// connect internals) differ between clusters by design and are skipped.
+func compareTopicSet(metadata *kafka.ClusterMetadata, excludeInternal bool, excludeTopics []string) map[string]*kafka.TopicInfo {
+	replication := kafka.DetectReplication(metadata.Topics)
+
+	topics := make(map[string]*kafka.TopicInfo, len(metadata.Topics))
+	for name, topic := range metadata.Topics {
+		if topic.Internal && excludeInternal {
+			continue
+		}
+		if shouldExcludeTopic(name, excludeTopics) {
+			continue
+		}
+		if info, ok := replication[name]; ok && info.Kind != kafka.ReplicationKindRemote {
+			continue
+		}
+		topics[name] = topic
+	}
+	return topics
+}
+
+func compareTopicConfigs(name, mapped string, sourceTopic, targetTopic *kafka.TopicInfo) []*reporter.CompareFinding {
+	sourceConfig := reporter.FilterInterestingConfig(sourceTopic.Config)
+	targetConfig := reporter.FilterInterestingConfig(targetTopic.Config)
+
+	keys := make(map[string]struct{}, len(sourceConfig)+len(targetConfig))
+	for key := range sourceConfig {
+		keys[key] = struct{}{}
+	}
+	for key := range targetConfig {
+		keys[key] = struct{}{}
+	}
+	ordered := make([]string, 0, len(keys))
+	for key := range keys {
+		ordered = append(ordered, key)
+	}
+	sort.Strings(ordered)
+
+	findings := make([]*reporter.CompareFinding, 0)
+	for _, key := range ordered {
+		if sourceConfig[key] == targetConfig[key] {
+			continue
+		}
+		findings = append(findings, &reporter.CompareFinding{
+			Kind:        reporter.CompareKindConfigMismatch,
+			Topic:       name,
+			TargetTopic: mapped,
+			Key:         key,
+			SourceValue: sourceConfig[key],
+			TargetValue: targetConfig[key],
+			Reason:      fmt.Sprintf("config %s differs between clusters", key),
+		})
+	}
+	return findings
+}
+
+// compareGroupOffsets reports source consumer groups whose committed offsets
+// have no translated counterpart on the target cluster.
+func compareGroupOffsets(source, target *kafka.ClusterMetadata, sourceTopics, targetTopics map[string]*kafka.TopicInfo, targetName func(string) string) []*reporter.CompareFinding {
+	groupIDs := make([]string, 0, len(source.ConsumerGroups))
+	for groupID := range source.ConsumerGroups {
+		groupIDs = append(groupIDs, groupID)
+	}
+	sort.Strings(groupIDs)
+
+	findings := make([]*reporter.CompareFinding, 0)
+	for _, groupID := range groupIDs {
+		sourceGroup := source.ConsumerGroups[groupID]
+		targetGroup := target.ConsumerGroups[groupID]
+
+		topics := make([]string, 0, len(sourceGroup.Offsets))
+		for topic := range sourceGroup.Offsets {
+			topics = append(topics, topic)
+		}
+		sort.Strings(topics)
+
+		for _, topic := range topics {
+			if _, ok := sourceTopics[topic]; !ok {
+				continue
+			}
+			mapped := targetName(topic)
+			if _, ok := targetTopics[mapped]; !ok {
+				// Already reported as MISSING_ON_TARGET.
+				continue
+			}
+
+			committed := len(sourceGroup.Offsets[topic])
+			if committed == 0 {
+				continue
+			}
+
+			if targetGroup == nil {
+				findings = append(findings, &reporter.CompareFinding{
+					Kind:        reporter.CompareKindOffsetGap,
+					Topic:       topic,
+					TargetTopic: mapped,
+					Group:       groupID,
+					Reason:      "consumer group has committed offsets on source but does not exist on target",
+				})
+				continue
+			}
+
+			translated := 0
+			for partition := range sourceGroup.Offsets[topic] {
+				if _, ok := targetGroup.Offsets[mapped][partition]; ok {
+					translated++
+				}
+			}
+			if translated == committed {
+				continue
+			}
+
+			findings = append(findings, &reporter.CompareFinding{
+				Kind:        reporter.CompareKindOffsetGap,
+				Topic:       topic,
+				TargetTopic: mapped,
+				Group:       groupID,
+				Key:         "translated_partitions",
+				SourceValue: strconv.Itoa(committed),
+				TargetValue: strconv.Itoa(translated),
+				Reason:      fmt.Sprintf("%d of %d committed partitions have no translated offset on target", committed-translated, committed),
+			})
+		}
+	}
+
+	return findings
+}
+
+func sortedTopicNames(topics map[string]*kafka.TopicInfo) []string {
+	names := make([]string, 0, len(topics))
+	for name := range topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
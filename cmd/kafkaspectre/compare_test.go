@@ -0,0 +1,192 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ppiankov/kafkaspectre/internal/kafka"
+	"github.com/ppiankov/kafkaspectre/internal/reporter"
+	"github.com/spf13/cobra"
+)
+
+func TestBuildCompareResult(t *testing.T) {
+	source := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"orders":   {Name: "orders", Partitions: 6, Config: map[string]string{"retention.ms": "604800000", "cleanup.policy": "delete"}},
+			"payments": {Name: "payments", Partitions: 3, Config: map[string]string{}},
+			"audit":    {Name: "audit", Partitions: 1, Config: map[string]string{}},
+		},
+		ConsumerGroups: map[string]*kafka.ConsumerGroupInfo{
+			"orders-cg": {
+				GroupID: "orders-cg",
+				Offsets: map[string]map[int32]int64{"orders": {0: 10, 1: 20, 2: 30}},
+			},
+			"payments-cg": {
+				GroupID: "payments-cg",
+				Offsets: map[string]map[int32]int64{"payments": {0: 5}},
+			},
+		},
+	}
+	target := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"primary.orders":               {Name: "primary.orders", Partitions: 6, Config: map[string]string{"retention.ms": "86400000", "cleanup.policy": "delete"}},
+			"primary.payments":             {Name: "primary.payments", Partitions: 1, Config: map[string]string{}},
+			"primary.heartbeats":           {Name: "primary.heartbeats", Partitions: 1},
+			"primary.checkpoints.internal": {Name: "primary.checkpoints.internal", Partitions: 1},
+			"local-only":                   {Name: "local-only", Partitions: 1},
+		},
+		ConsumerGroups: map[string]*kafka.ConsumerGroupInfo{
+			"orders-cg": {
+				GroupID: "orders-cg",
+				Offsets: map[string]map[int32]int64{"primary.orders": {0: 8, 1: 19}},
+			},
+		},
+	}
+
+	result := buildCompareResult(source, target, "primary", false, nil)
+	summary := result.Summary
+
+	if summary.SourceTopics != 3 || summary.TargetTopics != 3 || summary.MatchedTopics != 2 {
+		t.Fatalf("topic counts mismatch: %+v", summary)
+	}
+	if summary.MissingOnTargetCount != 1 || summary.MissingOnSourceCount != 1 {
+		t.Fatalf("missing counts mismatch: %+v", summary)
+	}
+	if summary.PartitionMismatchCount != 1 || summary.ConfigMismatchCount != 1 || summary.OffsetGapCount != 2 {
+		t.Fatalf("mismatch counts: %+v", summary)
+	}
+	if summary.TotalFindings != len(result.Findings) || summary.TotalFindings != 6 {
+		t.Fatalf("total findings = %d (len %d), want 6", summary.TotalFindings, len(result.Findings))
+	}
+
+	byKey := make(map[string]*reporter.CompareFinding)
+	for _, finding := range result.Findings {
+		byKey[string(finding.Kind)+"|"+finding.Topic+"|"+finding.Group] = finding
+	}
+
+	if got := byKey["MISSING_ON_TARGET|audit|"]; got == nil || got.TargetTopic != "primary.audit" {
+		t.Fatalf("missing audit finding: %+v", got)
+	}
+	if got := byKey["MISSING_ON_SOURCE|local-only|"]; got == nil {
+		t.Fatalf("expected local-only to be missing on source")
+	}
+	if got := byKey["CONFIG_MISMATCH|orders|"]; got == nil || got.Key != "retention.ms" || got.SourceValue != "604800000" || got.TargetValue != "86400000" {
+		t.Fatalf("config mismatch finding: %+v", got)
+	}
+	if got := byKey["OFFSET_TRANSLATION_GAP|orders|orders-cg"]; got == nil || got.SourceValue != "3" || got.TargetValue != "2" {
+		t.Fatalf("orders offset gap finding: %+v", got)
+	}
+	if got := byKey["OFFSET_TRANSLATION_GAP|payments|payments-cg"]; got == nil || !strings.Contains(got.Reason, "does not exist on target") {
+		t.Fatalf("payments offset gap finding: %+v", got)
+	}
+}
+
+func TestBuildCompareResultIdentityPolicy(t *testing.T) {
+	topics := func() map[string]*kafka.TopicInfo {
+		return map[string]*kafka.TopicInfo{
+			"orders":     {Name: "orders", Partitions: 3, Config: map[string]string{}},
+			"__internal": {Name: "__internal", Partitions: 1, Internal: true},
+		}
+	}
+	source := &kafka.ClusterMetadata{Topics: topics()}
+	target := &kafka.ClusterMetadata{Topics: topics()}
+	delete(target.Topics, "__internal")
+
+	result := buildCompareResult(source, target, "", true, nil)
+	if result.Summary.TotalFindings != 0 || result.Summary.MatchedTopics != 1 {
+		t.Fatalf("expected parity, got %+v", result.Summary)
+	}
+}
+
+func TestRunCompareValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		opts    compareOptions
+		wantErr string
+	}{
+		{
+			name:    "missing-source",
+			opts:    compareOptions{target: "dr:9092", output: "text"},
+			wantErr: "source bootstrap server is required",
+		},
+		{
+			name:    "missing-target",
+			opts:    compareOptions{source: "primary:9092", output: "text"},
+			wantErr: "target bootstrap server is required",
+		},
+		{
+			name:    "invalid-output",
+			opts:    compareOptions{source: "primary:9092", target: "dr:9092", output: "spectrehub"},
+			wantErr: "invalid output format",
+		},
+		{
+			name:    "invalid-alias",
+			opts:    compareOptions{source: "primary:9092", target: "dr:9092", output: "text", sourceAlias: "a.b"},
+			wantErr: "invalid source alias",
+		},
+		{
+			name:    "incomplete-target-auth",
+			opts:    compareOptions{source: "primary:9092", target: "dr:9092", output: "text", targetAuth: clusterAuth{authMechanism: "PLAIN", username: "dr"}},
+			wantErr: "target-auth-mechanism requires both --target-username and --target-password",
+		},
+		{
+			name:    "target-cert-without-key",
+			opts:    compareOptions{source: "primary:9092", target: "dr:9092", output: "text", targetAuth: clusterAuth{tlsCert: "dr.crt"}},
+			wantErr: "--target-tls-cert and --target-tls-key must be provided together",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := tc.opts
+			opts.timeout = defaultQueryTimeout
+
+			err := runCompare(&cobra.Command{}, opts)
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %q, want to contain %q", err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveTargetAuth(t *testing.T) {
+	shared := clusterAuth{authMechanism: "PLAIN", username: "primary", password: "secret", tlsEnabled: true, tlsCert: "primary.crt", tlsKey: "primary.key", tlsCA: "primary-ca.pem"}
+
+	cases := []struct {
+		name   string
+		target clusterAuth
+		tlsSet bool
+		want   clusterAuth
+	}{
+		{
+			name: "falls back to shared",
+			want: shared,
+		},
+		{
+			name:   "own credentials and CA",
+			target: clusterAuth{authMechanism: "SCRAM-SHA-512", username: "dr", password: "other", tlsCA: "dr-ca.pem"},
+			want:   clusterAuth{authMechanism: "SCRAM-SHA-512", username: "dr", password: "other", tlsEnabled: true, tlsCert: "primary.crt", tlsKey: "primary.key", tlsCA: "dr-ca.pem"},
+		},
+		{
+			name:   "username alone does not borrow the shared password",
+			target: clusterAuth{username: "dr", tlsCert: "dr.crt", tlsKey: "dr.key"},
+			want:   clusterAuth{username: "dr", tlsEnabled: true, tlsCert: "dr.crt", tlsKey: "dr.key", tlsCA: "primary-ca.pem"},
+		},
+		{
+			name:   "tls disabled for target",
+			tlsSet: true,
+			want:   clusterAuth{authMechanism: "PLAIN", username: "primary", password: "secret", tlsCert: "primary.crt", tlsKey: "primary.key", tlsCA: "primary-ca.pem"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolveTargetAuth(shared, tc.target, tc.tlsSet); got != tc.want {
+				t.Fatalf("resolveTargetAuth() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
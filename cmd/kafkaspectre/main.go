@@ -8,7 +8,9 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -113,11 +115,13 @@ type auditOptions struct {
 	output          string
 	excludeInternal bool
 	excludeTopics   []string
+	sourceAliases   []string
 	timeout         time.Duration
 }
 
 type checkOptions struct {
-	repo            string
+	repos           []string
+	manifest        string
 	bootstrapServer string
 	authMechanism   string
 	username        string
@@ -129,6 +133,16 @@ type checkOptions struct {
 	output          string
 	excludeInternal bool
 	excludeTopics   []string
+	templateVars    []string
+	minConfidence   float64
+	includePaths    []string
+	excludePaths    []string
+	maxFileSize     string
+	scanWorkers     int
+	cacheDir        string
+	scanArchives    bool
+	gitRef          string
+	changedSince    string
 	timeout         time.Duration
 }
 
@@ -149,6 +163,7 @@ func newRootCmd() *cobra.Command {
 
 	cmd.AddCommand(newAuditCmd())
 	cmd.AddCommand(newCheckCmd())
+	cmd.AddCommand(newCompareCmd())
 	cmd.AddCommand(newVersionCmd())
 
 	return cmd
@@ -201,6 +216,7 @@ func newAuditCmd() *cobra.Command {
 	flags.StringVar(&opts.output, "output", "text", "Output format (json|sarif|spectrehub|text)")
 	flags.BoolVar(&opts.excludeInternal, "exclude-internal", false, "Exclude internal topics from analysis")
 	flags.StringSliceVar(&opts.excludeTopics, "exclude-topics", nil, "Exclude topics by name or glob pattern (repeatable)")
+	flags.StringArrayVar(&opts.sourceAliases, "source-alias", nil, "MirrorMaker 2 source cluster alias replicating into this cluster (repeatable); needed when it has no heartbeats and checkpoints topics")
 	flags.DurationVar(&opts.timeout, "timeout", 0, "Kafka query timeout (for example: 10s, 1m)")
 
 	return cmd
@@ -222,7 +238,8 @@ func newCheckCmd() *cobra.Command {
 	}
 
 	flags := cmd.Flags()
-	flags.StringVar(&opts.repo, "repo", "", "Path to repository to scan for topic references")
+	flags.StringArrayVar(&opts.repos, "repo", nil, "Path to a repository to scan for topic references (repeatable)")
+	flags.StringVar(&opts.manifest, "manifest", "", "Path to a desired-state topic manifest (YAML) to compare against the cluster")
 	flags.StringVar(&opts.bootstrapServer, "bootstrap-server", "", "Kafka bootstrap server(s) (host:port, comma-separated)")
 	flags.StringVar(&opts.authMechanism, "auth-mechanism", "", "SASL mechanism (PLAIN, SCRAM-SHA-256, SCRAM-SHA-512)")
 	flags.StringVar(&opts.username, "username", "", "SASL username")
@@ -234,12 +251,18 @@ func newCheckCmd() *cobra.Command {
 	flags.StringVar(&opts.output, "output", "text", "Output format (json|sarif|spectrehub|text)")
 	flags.BoolVar(&opts.excludeInternal, "exclude-internal", false, "Exclude internal topics from analysis")
 	flags.StringSliceVar(&opts.excludeTopics, "exclude-topics", nil, "Exclude topics by name or glob pattern (repeatable)")
+	flags.Float64Var(&opts.minConfidence, "min-confidence", 0, "Ignore repository references scored below this confidence (0-1)")
+	flags.StringArrayVar(&opts.templateVars, "template-var", nil, "Value for a templated topic placeholder, e.g. env=prod (repeatable)")
+	flags.StringSliceVar(&opts.includePaths, "include-path", nil, "Only scan repository files matching this .gitignore-style glob (repeatable)")
+	flags.StringSliceVar(&opts.excludePaths, "exclude-path", nil, "Skip repository files and directories matching this .gitignore-style glob (repeatable)")
+	flags.StringVar(&opts.maxFileSize, "max-file-size", "", "Skip repository files larger than this size, e.g. 4MiB (default 2MiB)")
+	flags.StringVar(&opts.gitRef, "git-ref", "", "Scan the repository tree at this git revision instead of the working tree")
+	flags.StringVar(&opts.changedSince, "changed-since", "", "Only report topics referenced in files changed since this git revision")
+	flags.IntVar(&opts.scanWorkers, "scan-workers", 0, "Number of repository files parsed concurrently (default: number of CPUs)")
+	flags.StringVar(&opts.cacheDir, "cache-dir", "", "Directory for the per-file scan cache; unchanged files are not re-parsed on later runs")
+	flags.BoolVar(&opts.scanArchives, "scan-archives", false, "Also scan .jar, .war, .whl and .zip files for embedded configs and class string constants")
 	flags.DurationVar(&opts.timeout, "timeout", 0, "Kafka query timeout (for example: 10s, 1m)")
 
-	if err := cmd.MarkFlagRequired("repo"); err != nil {
-		panic(err)
-	}
-
 	return cmd
 }
 
@@ -259,6 +282,14 @@ func resolveAuditOptions(cmd *cobra.Command, opts auditOptions) (auditOptions, e
 	}
 	opts.excludeTopics = patterns
 
+	for i, alias := range opts.sourceAliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" || strings.Contains(alias, ".") {
+			return opts, fmt.Errorf("invalid source alias %q (must be non-empty and must not contain '.')", opts.sourceAliases[i])
+		}
+		opts.sourceAliases[i] = alias
+	}
+
 	if opts.timeout == 0 {
 		opts.timeout = defaultQueryTimeout
 	}
@@ -331,6 +362,43 @@ func applyCheckConfigDefaults(cmd *cobra.Command, opts checkOptions, cfg *config
 	if !flagChanged(cmd, "timeout") && cfg.HasTimeout {
 		opts.timeout = cfg.Timeout
 	}
+	if !flagChanged(cmd, "manifest") && strings.TrimSpace(opts.manifest) == "" && strings.TrimSpace(cfg.Manifest) != "" {
+		opts.manifest = cfg.Manifest
+	}
+	if !flagChanged(cmd, "min-confidence") && cfg.MinConfidence != nil {
+		opts.minConfidence = *cfg.MinConfidence
+	}
+	if !flagChanged(cmd, "repo") && len(opts.repos) == 0 && len(cfg.Repos) > 0 {
+		opts.repos = append([]string(nil), cfg.Repos...)
+	}
+	if !flagChanged(cmd, "include-path") && len(cfg.IncludePaths) > 0 {
+		opts.includePaths = append([]string(nil), cfg.IncludePaths...)
+	}
+	if !flagChanged(cmd, "exclude-path") && len(cfg.ExcludePaths) > 0 {
+		opts.excludePaths = append([]string(nil), cfg.ExcludePaths...)
+	}
+	if !flagChanged(cmd, "max-file-size") && cfg.MaxFileSize > 0 {
+		opts.maxFileSize = strconv.FormatInt(cfg.MaxFileSize, 10)
+	}
+	if !flagChanged(cmd, "scan-workers") && cfg.ScanWorkers > 0 {
+		opts.scanWorkers = cfg.ScanWorkers
+	}
+	if !flagChanged(cmd, "cache-dir") && strings.TrimSpace(opts.cacheDir) == "" && strings.TrimSpace(cfg.CacheDir) != "" {
+		opts.cacheDir = cfg.CacheDir
+	}
+	if !flagChanged(cmd, "scan-archives") && cfg.ScanArchives != nil {
+		opts.scanArchives = *cfg.ScanArchives
+	}
+	if !flagChanged(cmd, "template-var") && len(cfg.TemplateVars) > 0 {
+		keys := make([]string, 0, len(cfg.TemplateVars))
+		for key := range cfg.TemplateVars {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			opts.templateVars = append(opts.templateVars, key+"="+cfg.TemplateVars[key])
+		}
+	}
 
 	return opts
 }
@@ -405,7 +473,7 @@ func runAudit(cmd *cobra.Command, opts auditOptions) error {
 		return err
 	}
 
-	result := buildAuditResult(metadata, opts.excludeInternal, excludePatterns)
+	result := buildAuditResult(metadata, opts.excludeInternal, excludePatterns, opts.sourceAliases)
 	result.Tool = "kafkaspectre"
 	result.Version = Version
 	result.Timestamp = time.Now().UTC().Format(time.RFC3339)
@@ -504,20 +572,59 @@ func runCheck(cmd *cobra.Command, opts checkOptions) error {
 	if opts.timeout <= 0 {
 		return errors.New("timeout must be greater than zero")
 	}
-	if strings.TrimSpace(opts.repo) == "" {
+	var repos []string
+	for _, repo := range opts.repos {
+		if repo = strings.TrimSpace(repo); repo != "" {
+			repos = append(repos, repo)
+		}
+	}
+	if len(repos) == 0 {
 		return errors.New("repo path is required")
 	}
-
-	repoPath, err := filepath.Abs(opts.repo)
+	labels, err := checkRepoLabels(repos)
 	if err != nil {
-		return fmt.Errorf("resolve repo path: %w", err)
+		return err
+	}
+	templateVars, err := parseTemplateVars(opts.templateVars)
+	if err != nil {
+		return err
 	}
-	repoInfo, err := os.Stat(repoPath)
+	if opts.minConfidence < 0 || opts.minConfidence > 1 {
+		return errors.New("min-confidence must be between 0 and 1")
+	}
+	var maxFileSize int64
+	if strings.TrimSpace(opts.maxFileSize) != "" {
+		maxFileSize, err = config.ParseSize(opts.maxFileSize)
+		if err != nil {
+			return fmt.Errorf("max-file-size: %w", err)
+		}
+	}
+	repoScanner, err := scanner.NewRepoScannerWithConfig(scanner.Config{
+		MaxFileSize:  maxFileSize,
+		Include:      opts.includePaths,
+		Exclude:      opts.excludePaths,
+		Workers:      opts.scanWorkers,
+		CacheDir:     opts.cacheDir,
+		ScanArchives: opts.scanArchives,
+	})
 	if err != nil {
-		return fmt.Errorf("repo path %q: %w", opts.repo, err)
+		return err
 	}
-	if !repoInfo.IsDir() {
-		return fmt.Errorf("repo path %q is not a directory", opts.repo)
+
+	repoPaths := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		repoPath, err := filepath.Abs(repo)
+		if err != nil {
+			return fmt.Errorf("resolve repo path: %w", err)
+		}
+		repoInfo, err := os.Stat(repoPath)
+		if err != nil {
+			return fmt.Errorf("repo path %q: %w", repo, err)
+		}
+		if !repoInfo.IsDir() {
+			return fmt.Errorf("repo path %q is not a directory", repo)
+		}
+		repoPaths = append(repoPaths, repoPath)
 	}
 
 	kafkaCfg := kafka.Config{
@@ -548,13 +655,26 @@ func runCheck(cmd *cobra.Command, opts checkOptions) error {
 		return err
 	}
 
-	repoScanner := scanner.NewRepoScanner()
-	scanResult, err := repoScanner.Scan(cmd.Context(), repoPath)
+	scanResult, changedFiles, err := scanCheckRepos(cmd.Context(), repoScanner, repos, labels, repoPaths, strings.TrimSpace(opts.gitRef), strings.TrimSpace(opts.changedSince))
 	if err != nil {
 		return err
 	}
 
+	if strings.TrimSpace(opts.manifest) != "" {
+		declarations, err := loadManifestDeclarations(opts.manifest, repoPaths, labels)
+		if err != nil {
+			return err
+		}
+		scanResult.Declarations = append(scanResult.Declarations, declarations...)
+	}
+	scanner.ExpandTemplates(scanResult, templateVars)
+	scanner.FilterByConfidence(scanResult, opts.minConfidence)
+
 	result := buildCheckResult(scanResult, metadata, opts.excludeInternal, excludePatterns)
+	if strings.TrimSpace(opts.changedSince) != "" {
+		limitCheckResultToFiles(result, changedFiles)
+		result.Summary.ChangedSince = strings.TrimSpace(opts.changedSince)
+	}
 	result.Tool = "kafkaspectre"
 	result.Version = Version
 	result.Timestamp = time.Now().UTC().Format(time.RFC3339)
@@ -568,7 +688,7 @@ func runCheck(cmd *cobra.Command, opts checkOptions) error {
 		if err != nil {
 			return err
 		}
-		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Repository: %s\n", opts.repo)
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Repository: %s\n", strings.Join(repos, ", "))
 		if err != nil {
 			return err
 		}
@@ -627,7 +747,7 @@ func runCheck(cmd *cobra.Command, opts checkOptions) error {
 		"duration", time.Since(start),
 	)
 
-	findingsCount := result.Summary.TotalFindings - result.Summary.OKCount
+	findingsCount := result.Summary.TotalFindings - result.Summary.OKCount + result.Summary.DriftCount
 	if findingsCount > 0 {
 		return &FindingsError{Count: findingsCount}
 	}
@@ -635,11 +755,13 @@ func runCheck(cmd *cobra.Command, opts checkOptions) error {
 	return nil
 }
 
-func buildAuditResult(metadata *kafka.ClusterMetadata, excludeInternal bool, excludeTopics []string) *reporter.AuditResult {
+func buildAuditResult(metadata *kafka.ClusterMetadata, excludeInternal bool, excludeTopics, sourceAliases []string) *reporter.AuditResult {
 	consumersByTopic := buildConsumersByTopic(metadata)
+	replication := kafka.DetectReplication(metadata.Topics, sourceAliases...)
 
 	unusedTopics := make([]*reporter.UnusedTopic, 0)
 	activeTopics := make([]*reporter.ActiveTopic, 0)
+	replicatedTopics := make([]*reporter.ReplicatedTopic, 0)
 
 	internalTopics := 0
 	totalTopics := 0
@@ -661,10 +783,18 @@ func buildAuditResult(metadata *kafka.ClusterMetadata, excludeInternal bool, exc
 			continue
 		}
 
+		consumers := consumersByTopic[topic.Name]
+
+		// MirrorMaker 2 topics have no local consumers by design; keep them
+		// out of the unused/active analysis and attribute them to their source.
+		if info, ok := replication[topic.Name]; ok {
+			replicatedTopics = append(replicatedTopics, reporter.BuildReplicatedTopic(topic, info, consumers))
+			continue
+		}
+
 		totalTopics++
 		totalPartitions += topic.Partitions
 
-		consumers := consumersByTopic[topic.Name]
 		if len(consumers) == 0 {
 			risk, priority := classifyRisk(topic)
 			recommendation := recommendationForRisk(risk)
@@ -690,6 +820,9 @@ func buildAuditResult(metadata *kafka.ClusterMetadata, excludeInternal bool, exc
 	sort.Slice(activeTopics, func(i, j int) bool {
 		return activeTopics[i].Name < activeTopics[j].Name
 	})
+	sort.Slice(replicatedTopics, func(i, j int) bool {
+		return replicatedTopics[i].Name < replicatedTopics[j].Name
+	})
 
 	unusedCount := len(unusedTopics)
 	activeCount := len(activeTopics)
@@ -714,12 +847,14 @@ func buildAuditResult(metadata *kafka.ClusterMetadata, excludeInternal bool, exc
 		UnusedTopics:                 unusedCount,
 		ActiveTopics:                 activeCount,
 		InternalTopics:               internalExcluded,
+		ReplicatedTopics:             len(replicatedTopics),
 		UnusedPercentage:             unusedPercent,
 		TotalPartitions:              totalPartitions,
 		UnusedPartitions:             unusedPartitions,
 		ActivePartitions:             activePartitions,
 		UnusedPartitionsPercent:      unusedPartitionsPercent,
 		TotalConsumerGroups:          len(metadata.ConsumerGroups),
+		ReplicationSources:           kafka.ReplicationSources(replication),
 		HighRiskCount:                highRisk,
 		MediumRiskCount:              mediumRisk,
 		LowRiskCount:                 lowRisk,
@@ -729,14 +864,16 @@ func buildAuditResult(metadata *kafka.ClusterMetadata, excludeInternal bool, exc
 	}
 
 	return &reporter.AuditResult{
-		Summary:       summary,
-		UnusedTopics:  unusedTopics,
-		ActiveTopics:  activeTopics,
-		Metadata:      metadata,
-		TotalTopics:   totalTopics,
-		UnusedCount:   unusedCount,
-		ActiveCount:   activeCount,
-		InternalCount: internalTopics,
+		Summary:          summary,
+		UnusedTopics:     unusedTopics,
+		ActiveTopics:     activeTopics,
+		ReplicatedTopics: replicatedTopics,
+		Metadata:         metadata,
+		TotalTopics:      totalTopics,
+		UnusedCount:      unusedCount,
+		ActiveCount:      activeCount,
+		InternalCount:    internalTopics,
+		ReplicatedCount:  len(replicatedTopics),
 	}
 }
 
@@ -764,6 +901,61 @@ func buildConsumersByTopic(metadata *kafka.ClusterMetadata) map[string][]string
 	return consumersByTopic
 }
 
+// checkRepoLabels labels each repository of a multi-repo check by its
+// cleaned --repo value; a single repository gets the empty label. The same
+// repository given twice (./a and a) is rejected, since its references
+// could not be told apart.
+func checkRepoLabels(repos []string) ([]string, error) {
+	labels := make([]string, len(repos))
+	if len(repos) < 2 {
+		return labels, nil
+	}
+	seen := make(map[string]struct{}, len(repos))
+	for idx, repo := range repos {
+		labels[idx] = filepath.ToSlash(filepath.Clean(repo))
+		if _, ok := seen[labels[idx]]; ok {
+			return nil, fmt.Errorf("repo %q is given more than once", repo)
+		}
+		seen[labels[idx]] = struct{}{}
+	}
+	return labels, nil
+}
+
+// scanCheckRepos scans each repository and, when there is more than one,
+// merges the results with every reference labelled by its --repo value.
+// Changed files are keyed by the same label, which is empty for a single
+// repository.
+func scanCheckRepos(ctx context.Context, repoScanner *scanner.RepoScanner, repos, labels, repoPaths []string, gitRef, changedSince string) (*scanner.Result, map[string][]string, error) {
+	results := make([]*scanner.Result, 0, len(repoPaths))
+	changedFiles := make(map[string][]string)
+	for idx, repoPath := range repoPaths {
+		var (
+			result *scanner.Result
+			err    error
+		)
+		if gitRef != "" {
+			result, err = repoScanner.ScanRevision(ctx, repoPath, gitRef)
+		} else {
+			result, err = repoScanner.Scan(ctx, repoPath)
+		}
+		if err == nil && changedSince != "" {
+			changedFiles[labels[idx]], err = scanner.ChangedFiles(ctx, repoPath, changedSince, gitRef)
+		}
+		if err != nil {
+			if len(repos) > 1 {
+				return nil, nil, fmt.Errorf("repo %q: %w", repos[idx], err)
+			}
+			return nil, nil, err
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 1 {
+		return results[0], changedFiles, nil
+	}
+	return scanner.MergeResults(labels, results), changedFiles, nil
+}
+
 func buildCheckResult(scanResult *scanner.Result, metadata *kafka.ClusterMetadata, excludeInternal bool, excludeTopics []string) *reporter.CheckResult {
 	consumersByTopic := buildConsumersByTopic(metadata)
 
@@ -785,6 +977,8 @@ func buildCheckResult(scanResult *scanner.Result, metadata *kafka.ClusterMetadat
 		}
 		repoTopics[topic] = ref
 	}
+	matchPatternReferences(repoTopics, scanResult.Patterns, clusterTopics)
+	suppressedTopics := scanner.SuppressedTopics(scanResult)
 
 	allTopics := make(map[string]struct{}, len(clusterTopics)+len(repoTopics))
 	for topic := range repoTopics {
@@ -801,47 +995,90 @@ func buildCheckResult(scanResult *scanner.Result, metadata *kafka.ClusterMetadat
 	sort.Strings(names)
 
 	findings := make([]*reporter.CheckFinding, 0, len(names))
+	var suggestions []suggestionCandidate
 	summary := &reporter.CheckSummary{
 		RepoPath:      scanResult.RepoPath,
+		Repos:         scanResult.Repos,
+		RepoRoots:     scanResult.RepoRoots,
+		Revision:      scanResult.Revision,
 		FilesScanned:  scanResult.FilesScanned,
 		RepoTopics:    len(repoTopics),
 		ClusterTopics: len(clusterTopics),
-		TotalFindings: len(names),
+	}
+	for _, count := range scanResult.SkippedFiles {
+		summary.FilesSkipped += count
+	}
+
+	var suppressed []reporter.CheckSuppression
+	for _, ref := range scanResult.Suppressed {
+		if shouldExcludeTopic(ref.Topic, excludeTopics) {
+			continue
+		}
+		suppressed = append(suppressed, reporter.CheckSuppression{
+			Topic:  ref.Topic,
+			Repo:   ref.Repo,
+			File:   ref.File,
+			Line:   ref.Line,
+			Source: ref.Source,
+			Reason: ref.Reason,
+		})
+		summary.SuppressedReferences++
 	}
 
 	for _, topic := range names {
 		repoRef, referencedInRepo := repoTopics[topic]
 		_, inCluster := clusterTopics[topic]
+		// A kafkaspectre:ignore-topic annotation marks a cluster topic as
+		// intentionally unreferenced in the repo.
+		if suppression, ok := suppressedTopics[topic]; ok && !referencedInRepo {
+			suppressed = append(suppressed, reporter.CheckSuppression{
+				Topic:  topic,
+				Repo:   suppression.Repo,
+				File:   suppression.File,
+				Line:   suppression.Line,
+				Reason: suppression.Reason,
+			})
+			summary.SuppressedTopics++
+			continue
+		}
 		consumerGroups := append([]string(nil), consumersByTopic[topic]...)
 		hasConsumers := inCluster && len(consumerGroups) > 0
 
-		status, reason := classifyCheckStatus(referencedInRepo, inCluster, hasConsumers)
+		var directions []string
+		if repoRef != nil {
+			directions = referenceDirections(repoRef.Occurrences)
+		}
+
+		status, reason := classifyCheckStatus(referencedInRepo, inCluster, hasConsumers, directions)
+		if scanner.IsTemplateTopic(topic) {
+			reason = "templated topic name has unmapped placeholders; set them with --template-var (for example env=prod)"
+		}
 		finding := &reporter.CheckFinding{
 			Topic:            topic,
 			Status:           status,
 			ReferencedInRepo: referencedInRepo,
 			InCluster:        inCluster,
 			ConsumerGroups:   consumerGroups,
+			Directions:       directions,
 			Reason:           reason,
 		}
 		if repoRef != nil {
 			finding.References = convertCheckReferences(repoRef.Occurrences)
+			finding.Repos = checkReferenceRepos(finding.References)
+		}
+		if status == reporter.CheckStatusMissingInCluster || status == reporter.CheckStatusProducedMissing {
+			if suggestions == nil {
+				suggestions = suggestionCandidates(clusterTopics)
+			}
+			finding.Suggestions = suggestTopicNames(topic, suggestions)
 		}
 
 		findings = append(findings, finding)
-
-		switch status {
-		case reporter.CheckStatusOK:
-			summary.OKCount++
-		case reporter.CheckStatusMissingInCluster:
-			summary.MissingInClusterCount++
-		case reporter.CheckStatusUnreferencedInRepo:
-			summary.UnreferencedInRepoCount++
-		case reporter.CheckStatusUnused:
-			summary.UnusedCount++
-		}
+		countCheckStatus(summary, status)
 	}
 
+	summary.TotalFindings = len(findings)
+
 	sort.Slice(findings, func(i, j int) bool {
 		left := findings[i]
 		right := findings[j]
@@ -851,35 +1088,483 @@ func buildCheckResult(scanResult *scanner.Result, metadata *kafka.ClusterMetadat
 		return left.Topic < right.Topic
 	})
 
+	declarations := make([]scanner.TopicDeclaration, 0, len(scanResult.Declarations))
+	declaredTopics := make(map[string]struct{}, len(scanResult.Declarations))
+	for _, decl := range scanResult.Declarations {
+		if shouldExcludeTopic(decl.Topic, excludeTopics) {
+			continue
+		}
+		declarations = append(declarations, decl)
+		declaredTopics[decl.Topic] = struct{}{}
+	}
+	drift := buildDriftFindings(declarations, clusterTopics)
+	summary.DeclaredTopics = len(declaredTopics)
+	summary.DriftCount = len(drift)
+
 	return &reporter.CheckResult{
-		Summary:  summary,
-		Findings: findings,
+		Summary:    summary,
+		Findings:   findings,
+		Drift:      drift,
+		Suppressed: suppressed,
+	}
+}
+
+func countCheckStatus(summary *reporter.CheckSummary, status reporter.CheckStatus) {
+	switch status {
+	case reporter.CheckStatusOK:
+		summary.OKCount++
+	case reporter.CheckStatusMissingInCluster:
+		summary.MissingInClusterCount++
+	case reporter.CheckStatusUnreferencedInRepo:
+		summary.UnreferencedInRepoCount++
+	case reporter.CheckStatusUnused:
+		summary.UnusedCount++
+	case reporter.CheckStatusProducedMissing:
+		summary.ProducedMissingCount++
+	case reporter.CheckStatusConsumedNoGroup:
+		summary.ConsumedNoGroupCount++
 	}
 }
 
+// limitCheckResultToFiles keeps only findings, drift and suppressions with
+// a repository location in files, keyed by repo label, so a diff-scoped
+// check reports just the topics the change touches. A location inside an
+// archive (app.jar!/inner/path) counts as the archive. Status and
+// suppression counters are recomputed.
+func limitCheckResultToFiles(result *reporter.CheckResult, files map[string][]string) {
+	changed := make(map[string]struct{})
+	for repo, repoFiles := range files {
+		for _, file := range repoFiles {
+			changed[repo+"\x00"+file] = struct{}{}
+		}
+	}
+	isChanged := func(repo, file string) bool {
+		if archive, _, ok := strings.Cut(file, "!/"); ok {
+			file = archive
+		}
+		_, ok := changed[repo+"\x00"+file]
+		return ok
+	}
+
+	summary := result.Summary
+	summary.ChangedFiles = len(changed)
+	summary.OKCount = 0
+	summary.MissingInClusterCount = 0
+	summary.UnreferencedInRepoCount = 0
+	summary.UnusedCount = 0
+	summary.ProducedMissingCount = 0
+	summary.ConsumedNoGroupCount = 0
+
+	findings := result.Findings[:0]
+	for _, finding := range result.Findings {
+		touched := false
+		for _, ref := range finding.References {
+			if isChanged(ref.Repo, ref.File) {
+				touched = true
+				break
+			}
+		}
+		if !touched {
+			continue
+		}
+		findings = append(findings, finding)
+		countCheckStatus(summary, finding.Status)
+	}
+	result.Findings = findings
+	summary.TotalFindings = len(findings)
+
+	drift := result.Drift[:0]
+	for _, finding := range result.Drift {
+		if isChanged(finding.Repo, finding.File) {
+			drift = append(drift, finding)
+		}
+	}
+	result.Drift = drift
+	summary.DriftCount = len(drift)
+
+	summary.SuppressedReferences = 0
+	summary.SuppressedTopics = 0
+	suppressed := result.Suppressed[:0]
+	for _, suppression := range result.Suppressed {
+		if !isChanged(suppression.Repo, suppression.File) {
+			continue
+		}
+		suppressed = append(suppressed, suppression)
+		// Suppressed cluster topics carry no reference source.
+		if suppression.Source == "" {
+			summary.SuppressedTopics++
+		} else {
+			summary.SuppressedReferences++
+		}
+	}
+	result.Suppressed = suppressed
+}
+
+// maxTopicSuggestions caps how many near-miss cluster topics are suggested
+// for a missing topic.
+const maxTopicSuggestions = 3
+
+// suggestionCandidate is a non-internal cluster topic with its normalised
+// name, computed once per check rather than once per missing topic.
+type suggestionCandidate struct {
+	name       string
+	normalized string
+}
+
+func suggestionCandidates(clusterTopics map[string]*kafka.TopicInfo) []suggestionCandidate {
+	candidates := make([]suggestionCandidate, 0, len(clusterTopics))
+	for name, info := range clusterTopics {
+		if info.Internal {
+			continue
+		}
+		candidates = append(candidates, suggestionCandidate{name: name, normalized: normalizeTopicName(name)})
+	}
+	return candidates
+}
+
+// suggestTopicNames returns the cluster topics closest to a missing topic.
+// Names are compared in normalised form (lower case, '.', '_' and '-'
+// treated alike), so separator mix-ups rank first, followed by small edit
+// distances such as order-created vs orders-created. Names whose length
+// alone puts them over the distance limit are skipped without computing
+// the distance.
+func suggestTopicNames(topic string, clusterTopics []suggestionCandidate) []string {
+	if scanner.IsTemplateTopic(topic) {
+		return nil
+	}
+
+	normalized := normalizeTopicName(topic)
+	limit := len(normalized) / 4
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 3 {
+		limit = 3
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	candidates := make([]candidate, 0)
+	for _, cluster := range clusterTopics {
+		if lengthDiff := len(cluster.normalized) - len(normalized); cluster.name == topic || lengthDiff > limit || lengthDiff < -limit {
+			continue
+		}
+		distance := levenshtein(normalized, cluster.normalized)
+		if distance <= limit {
+			candidates = append(candidates, candidate{name: cluster.name, distance: distance})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	if len(candidates) > maxTopicSuggestions {
+		candidates = candidates[:maxTopicSuggestions]
+	}
+	out := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		out = append(out, c.name)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func normalizeTopicName(topic string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '-':
+			return '.'
+		}
+		return r
+	}, strings.ToLower(topic))
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// matchPatternReferences attributes cluster topics matched by a subscription
+// pattern to the pattern's location. Like Kafka's pattern subscription, the
+// pattern must match the whole name and internal topics are not matched.
+// Matched topics get a copied TopicReference so the scan result is unchanged.
+func matchPatternReferences(repoTopics map[string]*scanner.TopicReference, patterns []scanner.PatternReference, clusterTopics map[string]*kafka.TopicInfo) {
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("^(?:" + pattern.Pattern + ")$")
+		if err != nil {
+			continue
+		}
+		for name, topic := range clusterTopics {
+			if topic.Internal || !re.MatchString(name) {
+				continue
+			}
+
+			existing := repoTopics[name]
+			ref := &scanner.TopicReference{Topic: name}
+			if existing != nil {
+				ref.Occurrences = append(ref.Occurrences, existing.Occurrences...)
+			}
+			ref.Occurrences = append(ref.Occurrences, scanner.Reference{
+				Topic:      name,
+				Repo:       pattern.Repo,
+				File:       pattern.File,
+				Line:       pattern.Line,
+				Column:     pattern.Column,
+				Source:     pattern.Source,
+				Direction:  pattern.Direction,
+				Pattern:    pattern.Pattern,
+				Confidence: scanner.PatternConfidence,
+			})
+			repoTopics[name] = ref
+		}
+	}
+}
+
+// buildDriftFindings compares declared topic definitions with the live
+// cluster. Only keys that are declared are compared, and a topic declared
+// several times is compared once, preferring its manifest entry. Cluster
+// topics are reported as undeclared only against a --manifest, which is the
+// complete desired state; Terraform, Strimzi or AsyncAPI files in a repo
+// usually cover just part of the cluster.
+func buildDriftFindings(declarations []scanner.TopicDeclaration, clusterTopics map[string]*kafka.TopicInfo) []*reporter.DriftFinding {
+	drift := make([]*reporter.DriftFinding, 0)
+	if len(declarations) == 0 {
+		return drift
+	}
+
+	declared := make(map[string]int, len(declarations))
+	unique := make([]scanner.TopicDeclaration, 0, len(declarations))
+	fromManifest := false
+	for _, decl := range declarations {
+		isManifest := decl.Source == scanner.SourceManifest
+		fromManifest = fromManifest || isManifest
+		if i, ok := declared[decl.Topic]; ok {
+			if isManifest && unique[i].Source != scanner.SourceManifest {
+				unique[i] = decl
+			}
+			continue
+		}
+		declared[decl.Topic] = len(unique)
+		unique = append(unique, decl)
+	}
+
+	for _, decl := range unique {
+
+		base := reporter.DriftFinding{
+			Topic:  decl.Topic,
+			Repo:   decl.Repo,
+			File:   decl.File,
+			Line:   decl.Line,
+			Source: decl.Source,
+		}
+
+		topic, ok := clusterTopics[decl.Topic]
+		if !ok {
+			finding := base
+			finding.Kind = reporter.DriftKindMissingTopic
+			finding.Reason = "topic is declared but does not exist in cluster"
+			drift = append(drift, &finding)
+			continue
+		}
+
+		if decl.Partitions > 0 && decl.Partitions != topic.Partitions {
+			finding := base
+			finding.Kind = reporter.DriftKindPartitions
+			finding.Key = "partitions"
+			finding.Expected = strconv.Itoa(decl.Partitions)
+			finding.Actual = strconv.Itoa(topic.Partitions)
+			finding.Reason = "partition count differs from declared value"
+			drift = append(drift, &finding)
+		}
+		if decl.ReplicationFactor > 0 && decl.ReplicationFactor != topic.ReplicationFactor {
+			finding := base
+			finding.Kind = reporter.DriftKindReplicationFactor
+			finding.Key = "replication_factor"
+			finding.Expected = strconv.Itoa(decl.ReplicationFactor)
+			finding.Actual = strconv.Itoa(topic.ReplicationFactor)
+			finding.Reason = "replication factor differs from declared value"
+			drift = append(drift, &finding)
+		}
+
+		keys := make([]string, 0, len(decl.Config))
+		for key := range decl.Config {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			actual := topic.Config[key]
+			if decl.Config[key] == actual {
+				continue
+			}
+			finding := base
+			finding.Kind = reporter.DriftKindConfig
+			finding.Key = key
+			finding.Expected = decl.Config[key]
+			finding.Actual = actual
+			finding.Reason = fmt.Sprintf("config %s differs from declared value", key)
+			drift = append(drift, &finding)
+		}
+	}
+
+	if fromManifest {
+		for _, name := range sortedTopicNames(clusterTopics) {
+			if _, ok := declared[name]; ok || clusterTopics[name].Internal {
+				continue
+			}
+			drift = append(drift, &reporter.DriftFinding{
+				Topic:  name,
+				Kind:   reporter.DriftKindUndeclaredTopic,
+				Reason: "topic exists in cluster but is not declared in the manifest",
+			})
+		}
+	}
+
+	sort.SliceStable(drift, func(i, j int) bool {
+		if drift[i].Topic != drift[j].Topic {
+			return drift[i].Topic < drift[j].Topic
+		}
+		return drift[i].Kind < drift[j].Kind
+	})
+
+	return drift
+}
+
+// loadManifestDeclarations loads a manifest and reports its path relative to
+// the scanned repository when it lives inside it.
+func loadManifestDeclarations(manifestPath string, repoPaths, labels []string) ([]scanner.TopicDeclaration, error) {
+	absManifest, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve manifest path: %w", err)
+	}
+
+	declarations, err := scanner.LoadManifest(absManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	// A manifest inside one of the repositories is attributed to it like
+	// any scanned file, so locations and --changed-since resolve against
+	// that repository.
+	display, repo := manifestPath, ""
+	for idx, repoPath := range repoPaths {
+		if rel, err := filepath.Rel(repoPath, absManifest); err == nil && !strings.HasPrefix(rel, "..") {
+			display, repo = filepath.ToSlash(rel), labels[idx]
+			break
+		}
+	}
+	for i := range declarations {
+		declarations[i].File = display
+		declarations[i].Repo = repo
+	}
+
+	return declarations, nil
+}
+
+// checkReferenceRepos lists the repositories referencing a topic in a
+// multi-repo check.
+func checkReferenceRepos(refs []reporter.CheckReference) []string {
+	var repos []string
+	for _, ref := range refs {
+		if ref.Repo != "" && !containsString(repos, ref.Repo) {
+			repos = append(repos, ref.Repo)
+		}
+	}
+	sort.Strings(repos)
+	return repos
+}
+
 func convertCheckReferences(refs []scanner.Reference) []reporter.CheckReference {
 	out := make([]reporter.CheckReference, 0, len(refs))
 	for _, ref := range refs {
 		out = append(out, reporter.CheckReference{
-			File:   ref.File,
-			Line:   ref.Line,
-			Source: ref.Source,
+			Repo:          ref.Repo,
+			File:          ref.File,
+			Line:          ref.Line,
+			Column:        ref.Column,
+			Source:        ref.Source,
+			Direction:     ref.Direction,
+			LiteralColumn: ref.LiteralColumn,
+			Pattern:       ref.Pattern,
+			Template:      ref.Template,
+			Resolution:    formatResolution(ref.Resolution),
+			Confidence:    ref.Confidence,
 		})
 	}
 
 	sort.Slice(out, func(i, j int) bool {
+		if out[i].Repo != out[j].Repo {
+			return out[i].Repo < out[j].Repo
+		}
 		if out[i].File != out[j].File {
 			return out[i].File < out[j].File
 		}
 		if out[i].Line != out[j].Line {
 			return out[i].Line < out[j].Line
 		}
+		if out[i].Column != out[j].Column {
+			return out[i].Column < out[j].Column
+		}
 		return out[i].Source < out[j].Source
 	})
 
 	return out
 }
 
+// formatResolution renders a placeholder resolution chain, e.g.
+// "KAFKA_TOPIC=orders.${ENV} (.env:2) -> ENV=prod (.env:1)".
+func formatResolution(chain []scanner.VariableBinding) string {
+	parts := make([]string, 0, len(chain))
+	for _, binding := range chain {
+		switch {
+		case binding.Default:
+			parts = append(parts, fmt.Sprintf("%s=%s (default)", binding.Name, binding.Value))
+		case binding.Line > 0:
+			parts = append(parts, fmt.Sprintf("%s=%s (%s:%d)", binding.Name, binding.Value, binding.File, binding.Line))
+		default:
+			parts = append(parts, fmt.Sprintf("%s=%s (%s)", binding.Name, binding.Value, binding.File))
+		}
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// parseTemplateVars parses --template-var key=value pairs.
+func parseTemplateVars(values []string) (map[string]string, error) {
+	vars := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid template var %q (expected key=value)", value)
+		}
+		vars[key] = strings.TrimSpace(val)
+	}
+	return vars, nil
+}
+
 func normalizeExcludePatterns(patterns []string) ([]string, error) {
 	if len(patterns) == 0 {
 		return nil, nil
@@ -933,10 +1618,39 @@ func metadataStats(metadata *kafka.ClusterMetadata) (topicCount int, partitionCo
 	return topicCount, partitionCount
 }
 
-func classifyCheckStatus(referencedInRepo, inCluster, hasConsumers bool) (reporter.CheckStatus, string) {
+// referenceDirections returns the sorted set of known directions (produce,
+// consume, admin) across a topic's references.
+func referenceDirections(refs []scanner.Reference) []string {
+	seen := make(map[string]struct{})
+	for _, ref := range refs {
+		if ref.Direction == "" || ref.Direction == scanner.DirectionUnknown {
+			continue
+		}
+		seen[ref.Direction] = struct{}{}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+
+	out := make([]string, 0, len(seen))
+	for direction := range seen {
+		out = append(out, direction)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func classifyCheckStatus(referencedInRepo, inCluster, hasConsumers bool, directions []string) (reporter.CheckStatus, string) {
+	produced := containsString(directions, scanner.DirectionProduce)
+	consumed := containsString(directions, scanner.DirectionConsume)
+
 	switch {
+	case referencedInRepo && !inCluster && produced:
+		return reporter.CheckStatusProducedMissing, "topic is produced to in code but does not exist in cluster"
 	case referencedInRepo && !inCluster:
 		return reporter.CheckStatusMissingInCluster, "topic is referenced in code but does not exist in cluster"
+	case inCluster && !hasConsumers && consumed:
+		return reporter.CheckStatusConsumedNoGroup, "topic is consumed in code but no consumer group exists in cluster"
 	case inCluster && !hasConsumers:
 		if referencedInRepo {
 			return reporter.CheckStatusUnused, "topic is referenced in code and exists in cluster but has no active consumer groups"
@@ -951,19 +1665,32 @@ func classifyCheckStatus(referencedInRepo, inCluster, hasConsumers bool) (report
 
 func checkStatusSortValue(status reporter.CheckStatus) int {
 	switch status {
-	case reporter.CheckStatusMissingInCluster:
+	case reporter.CheckStatusProducedMissing:
 		return 0
-	case reporter.CheckStatusUnused:
+	case reporter.CheckStatusMissingInCluster:
 		return 1
-	case reporter.CheckStatusUnreferencedInRepo:
+	case reporter.CheckStatusConsumedNoGroup:
 		return 2
-	case reporter.CheckStatusOK:
+	case reporter.CheckStatusUnused:
 		return 3
-	default:
+	case reporter.CheckStatusUnreferencedInRepo:
 		return 4
+	case reporter.CheckStatusOK:
+		return 5
+	default:
+		return 6
 	}
 }
 
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
+
 func classifyRisk(topic *kafka.TopicInfo) (string, int) {
 	if topic.Partitions >= 10 || topic.ReplicationFactor >= 3 {
 		return "high", 3
@@ -92,7 +92,7 @@ func TestBuildAuditResult(t *testing.T) {
 	}
 
 	t.Run("exclude-internal", func(t *testing.T) {
-		result := buildAuditResult(newMetadata(), true, nil)
+		result := buildAuditResult(newMetadata(), true, nil, nil)
 
 		if result.TotalTopics != 4 || result.InternalCount != 1 {
 			t.Fatalf("topic counts mismatch: total=%d internal=%d", result.TotalTopics, result.InternalCount)
@@ -138,7 +138,7 @@ func TestBuildAuditResult(t *testing.T) {
 	})
 
 	t.Run("include-internal", func(t *testing.T) {
-		result := buildAuditResult(newMetadata(), false, nil)
+		result := buildAuditResult(newMetadata(), false, nil, nil)
 
 		if result.TotalTopics != 5 || result.InternalCount != 1 {
 			t.Fatalf("topic counts mismatch: total=%d internal=%d", result.TotalTopics, result.InternalCount)
@@ -195,7 +195,7 @@ func TestBuildAuditResultExcludePatterns(t *testing.T) {
 		},
 	}
 
-	result := buildAuditResult(metadata, false, []string{"skip-*", "__*"})
+	result := buildAuditResult(metadata, false, []string{"skip-*", "__*"}, nil)
 
 	if result.TotalTopics != 2 || result.ActiveCount != 1 || result.UnusedCount != 1 {
 		t.Fatalf("unexpected counts: total=%d active=%d unused=%d", result.TotalTopics, result.ActiveCount, result.UnusedCount)
@@ -205,6 +205,60 @@ func TestBuildAuditResultExcludePatterns(t *testing.T) {
 	}
 }
 
+func TestBuildAuditResultMirrorMaker(t *testing.T) {
+	metadata := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"orders":                      {Name: "orders", Partitions: 3, ReplicationFactor: 3},
+			"source.orders":               {Name: "source.orders", Partitions: 12, ReplicationFactor: 3},
+			"source.heartbeats":           {Name: "source.heartbeats", Partitions: 1, ReplicationFactor: 3},
+			"source.checkpoints.internal": {Name: "source.checkpoints.internal", Partitions: 1, ReplicationFactor: 3},
+			"stale":                       {Name: "stale", Partitions: 1, ReplicationFactor: 1},
+		},
+		ConsumerGroups: map[string]*kafka.ConsumerGroupInfo{
+			"cg": {GroupID: "cg", Topics: []string{"orders"}},
+		},
+	}
+
+	result := buildAuditResult(metadata, false, nil, nil)
+
+	if result.TotalTopics != 2 || result.UnusedCount != 1 || result.ActiveCount != 1 {
+		t.Fatalf("unexpected counts: total=%d unused=%d active=%d", result.TotalTopics, result.UnusedCount, result.ActiveCount)
+	}
+	if got := unusedNames(result.UnusedTopics); !reflect.DeepEqual(got, []string{"stale"}) {
+		t.Fatalf("unused topics = %v, want [stale]", got)
+	}
+	if result.ReplicatedCount != 3 || result.Summary.ReplicatedTopics != 3 {
+		t.Fatalf("replicated count = %d (summary %d), want 3", result.ReplicatedCount, result.Summary.ReplicatedTopics)
+	}
+	if !reflect.DeepEqual(result.Summary.ReplicationSources, []string{"source"}) {
+		t.Fatalf("replication sources = %v, want [source]", result.Summary.ReplicationSources)
+	}
+
+	remote := result.ReplicatedTopics[len(result.ReplicatedTopics)-1]
+	if remote.Name != "source.orders" || remote.Kind != kafka.ReplicationKindRemote || remote.SourceCluster != "source" || remote.SourceTopic != "orders" {
+		t.Fatalf("remote topic mismatch: %+v", remote)
+	}
+}
+
+func TestBuildAuditResultAppHeartbeatsTopic(t *testing.T) {
+	metadata := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"billing.heartbeats": {Name: "billing.heartbeats", Partitions: 1, ReplicationFactor: 3},
+			"billing.invoices":   {Name: "billing.invoices", Partitions: 3, ReplicationFactor: 3},
+		},
+	}
+
+	result := buildAuditResult(metadata, false, nil, nil)
+	if result.ReplicatedCount != 0 || result.UnusedCount != 2 {
+		t.Fatalf("replicated = %d, unused = %v, want no replicas and both topics unused", result.ReplicatedCount, unusedNames(result.UnusedTopics))
+	}
+
+	result = buildAuditResult(metadata, false, nil, []string{"billing"})
+	if result.ReplicatedCount != 2 || result.UnusedCount != 0 {
+		t.Fatalf("with --source-alias: replicated = %d, unused = %v, want both topics replicated", result.ReplicatedCount, unusedNames(result.UnusedTopics))
+	}
+}
+
 func TestBuildCheckResult(t *testing.T) {
 	metadata := &kafka.ClusterMetadata{
 		Topics: map[string]*kafka.TopicInfo{
@@ -670,7 +724,7 @@ func TestRunCheckValidation(t *testing.T) {
 	}
 
 	base := checkOptions{
-		repo:            repoDir,
+		repos:           []string{repoDir},
 		bootstrapServer: "localhost:9092",
 		output:          "text",
 		timeout:         defaultQueryTimeout,
@@ -684,7 +738,7 @@ func TestRunCheckValidation(t *testing.T) {
 		{
 			name: "invalid-output",
 			opts: checkOptions{
-				repo:            repoDir,
+				repos:           []string{repoDir},
 				bootstrapServer: base.bootstrapServer,
 				output:          "yaml",
 			},
@@ -693,7 +747,7 @@ func TestRunCheckValidation(t *testing.T) {
 		{
 			name: "auth-missing-password",
 			opts: checkOptions{
-				repo:            repoDir,
+				repos:           []string{repoDir},
 				bootstrapServer: base.bootstrapServer,
 				output:          base.output,
 				authMechanism:   "PLAIN",
@@ -704,7 +758,7 @@ func TestRunCheckValidation(t *testing.T) {
 		{
 			name: "tls-cert-without-key",
 			opts: checkOptions{
-				repo:            repoDir,
+				repos:           []string{repoDir},
 				bootstrapServer: base.bootstrapServer,
 				output:          base.output,
 				tlsCert:         "/tmp/client.crt",
@@ -714,7 +768,7 @@ func TestRunCheckValidation(t *testing.T) {
 		{
 			name: "missing-repo",
 			opts: checkOptions{
-				repo:            filepath.Join(t.TempDir(), "missing"),
+				repos:           []string{filepath.Join(t.TempDir(), "missing")},
 				bootstrapServer: base.bootstrapServer,
 				output:          base.output,
 			},
@@ -723,12 +777,32 @@ func TestRunCheckValidation(t *testing.T) {
 		{
 			name: "repo-not-directory",
 			opts: checkOptions{
-				repo:            notDirFile,
+				repos:           []string{notDirFile},
 				bootstrapServer: base.bootstrapServer,
 				output:          base.output,
 			},
 			wantErr: "not a directory",
 		},
+		{
+			name: "invalid-template-var",
+			opts: checkOptions{
+				repos:           []string{repoDir},
+				bootstrapServer: base.bootstrapServer,
+				output:          base.output,
+				templateVars:    []string{"env"},
+			},
+			wantErr: "invalid template var",
+		},
+		{
+			name: "min-confidence-out-of-range",
+			opts: checkOptions{
+				repos:           []string{repoDir},
+				bootstrapServer: base.bootstrapServer,
+				output:          base.output,
+				minConfidence:   1.5,
+			},
+			wantErr: "min-confidence must be between 0 and 1",
+		},
 	}
 
 	for _, tc := range cases {
@@ -831,3 +905,458 @@ func withWorkingDir(t *testing.T, dir string) {
 		}
 	})
 }
+
+func TestBuildCheckResultDrift(t *testing.T) {
+	metadata := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"orders": {
+				Name:              "orders",
+				Partitions:        6,
+				ReplicationFactor: 3,
+				Config:            map[string]string{"retention.ms": "86400000"},
+			},
+			"payments": {Name: "payments", Partitions: 3, ReplicationFactor: 3},
+			"stray":    {Name: "stray", Partitions: 1, ReplicationFactor: 1},
+			"__consumer_offsets": {
+				Name:     "__consumer_offsets",
+				Internal: true,
+			},
+		},
+	}
+	scanResult := &scanner.Result{
+		RepoPath: "/tmp/repo",
+		Topics:   map[string]*scanner.TopicReference{},
+		Declarations: []scanner.TopicDeclaration{
+			{
+				Topic:             "orders",
+				File:              "topics.yaml",
+				Line:              2,
+				Source:            scanner.SourceManifest,
+				Partitions:        12,
+				ReplicationFactor: 3,
+				Config:            map[string]string{"retention.ms": "604800000"},
+			},
+			{Topic: "payments", File: "topics.yaml", Line: 8, Source: scanner.SourceManifest, Partitions: 3},
+			{Topic: "shipments", File: "topics.yaml", Line: 10, Source: scanner.SourceManifest},
+		},
+	}
+
+	result := buildCheckResult(scanResult, metadata, false, nil)
+	if result.Summary.DeclaredTopics != 3 || result.Summary.DriftCount != 4 {
+		t.Fatalf("summary mismatch: %+v", result.Summary)
+	}
+
+	got := make([]string, 0, len(result.Drift))
+	for _, finding := range result.Drift {
+		got = append(got, finding.Topic+":"+string(finding.Kind)+":"+finding.Expected+":"+finding.Actual)
+	}
+	want := []string{
+		"orders:CONFIG_MISMATCH:604800000:86400000",
+		"orders:PARTITIONS_MISMATCH:12:6",
+		"shipments:MISSING_TOPIC::",
+		"stray:UNDECLARED_TOPIC::",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("drift = %#v, want %#v", got, want)
+	}
+	if result.Drift[0].File != "topics.yaml" || result.Drift[0].Line != 2 {
+		t.Fatalf("drift location = %s:%d", result.Drift[0].File, result.Drift[0].Line)
+	}
+
+	scanResult.Declarations = nil
+	result = buildCheckResult(scanResult, metadata, false, nil)
+	if len(result.Drift) != 0 || result.Summary.DriftCount != 0 {
+		t.Fatalf("expected no drift without declarations, got %+v", result.Drift)
+	}
+}
+
+func TestBuildCheckResultDriftWithoutManifest(t *testing.T) {
+	metadata := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"orders": {Name: "orders", Partitions: 6, ReplicationFactor: 3},
+			"stray":  {Name: "stray", Partitions: 1, ReplicationFactor: 1},
+		},
+	}
+	scanResult := &scanner.Result{
+		RepoPath: "/tmp/repo",
+		Topics:   map[string]*scanner.TopicReference{},
+		Declarations: []scanner.TopicDeclaration{
+			{Topic: "orders", File: "infra/topics.tf", Line: 1, Source: scanner.SourceTerraform, Partitions: 12},
+			{Topic: "orders", File: "k8s/orders.yaml", Line: 5, Source: scanner.SourceStrimzi, Partitions: 12},
+		},
+	}
+
+	result := buildCheckResult(scanResult, metadata, false, nil)
+	if len(result.Drift) != 1 || result.Summary.DriftCount != 1 {
+		t.Fatalf("drift = %#v, want one partition finding and no UNDECLARED_TOPIC", result.Drift)
+	}
+	if got := result.Drift[0]; got.Kind != reporter.DriftKindPartitions || got.File != "infra/topics.tf" {
+		t.Fatalf("drift = %#v, want partitions drift at the first declaration", got)
+	}
+
+	scanResult.Declarations = append(scanResult.Declarations, scanner.TopicDeclaration{Topic: "orders", File: "topics.yaml", Line: 2, Source: scanner.SourceManifest, Partitions: 6})
+	result = buildCheckResult(scanResult, metadata, false, nil)
+	if len(result.Drift) != 1 || result.Drift[0].Kind != reporter.DriftKindUndeclaredTopic || result.Drift[0].Topic != "stray" {
+		t.Fatalf("drift = %#v, want the manifest entry to win and stray undeclared", result.Drift)
+	}
+}
+
+func TestLoadManifestDeclarationsRelativePath(t *testing.T) {
+	repoPath := t.TempDir()
+	manifestPath := filepath.Join(repoPath, "deploy", "topics.yaml")
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(manifestPath, []byte("topics:\n  - name: orders\n"), 0o644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+
+	declarations, err := loadManifestDeclarations(manifestPath, []string{repoPath}, []string{""})
+	if err != nil {
+		t.Fatalf("loadManifestDeclarations() error = %v", err)
+	}
+	if len(declarations) != 1 || declarations[0].File != "deploy/topics.yaml" || declarations[0].Repo != "" {
+		t.Fatalf("declarations = %+v", declarations)
+	}
+
+	// In a multi-repo check the manifest belongs to the repo containing it.
+	otherPath := t.TempDir()
+	declarations, err = loadManifestDeclarations(manifestPath, []string{otherPath, repoPath}, []string{"../other", "infra"})
+	if err != nil {
+		t.Fatalf("loadManifestDeclarations() error = %v", err)
+	}
+	if len(declarations) != 1 || declarations[0].File != "deploy/topics.yaml" || declarations[0].Repo != "infra" {
+		t.Fatalf("multi-repo declarations = %+v", declarations)
+	}
+}
+
+func TestCheckRepoLabels(t *testing.T) {
+	labels, err := checkRepoLabels([]string{"./orders"})
+	if err != nil || !reflect.DeepEqual(labels, []string{""}) {
+		t.Fatalf("single repo labels = %#v, err = %v", labels, err)
+	}
+
+	labels, err = checkRepoLabels([]string{"./orders", "../billing/", "/srv/infra"})
+	if err != nil || !reflect.DeepEqual(labels, []string{"orders", "../billing", "/srv/infra"}) {
+		t.Fatalf("labels = %#v, err = %v", labels, err)
+	}
+
+	if _, err := checkRepoLabels([]string{"./a", "b", "a"}); err == nil || !strings.Contains(err.Error(), `repo "a" is given more than once`) {
+		t.Fatalf("duplicate label error = %v", err)
+	}
+}
+
+func TestBuildCheckResultDirections(t *testing.T) {
+	metadata := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"consumed.idle":   {Name: "consumed.idle", Partitions: 1, ReplicationFactor: 1},
+			"referenced.idle": {Name: "referenced.idle", Partitions: 1, ReplicationFactor: 1},
+		},
+		ConsumerGroups: map[string]*kafka.ConsumerGroupInfo{},
+	}
+	ref := func(topic, direction string) *scanner.TopicReference {
+		return &scanner.TopicReference{
+			Topic:       topic,
+			Occurrences: []scanner.Reference{{Topic: topic, File: "src/App.java", Line: 3, Source: scanner.SourceRegex, Direction: direction}},
+		}
+	}
+	scanResult := &scanner.Result{
+		RepoPath: "/tmp/repo",
+		Topics: map[string]*scanner.TopicReference{
+			"produced.missing":   ref("produced.missing", scanner.DirectionProduce),
+			"referenced.missing": ref("referenced.missing", scanner.DirectionUnknown),
+			"consumed.idle":      ref("consumed.idle", scanner.DirectionConsume),
+			"referenced.idle":    ref("referenced.idle", scanner.DirectionAdmin),
+		},
+	}
+
+	result := buildCheckResult(scanResult, metadata, false, nil)
+
+	got := make(map[string]reporter.CheckStatus, len(result.Findings))
+	for _, finding := range result.Findings {
+		got[finding.Topic] = finding.Status
+	}
+	want := map[string]reporter.CheckStatus{
+		"produced.missing":   reporter.CheckStatusProducedMissing,
+		"referenced.missing": reporter.CheckStatusMissingInCluster,
+		"consumed.idle":      reporter.CheckStatusConsumedNoGroup,
+		"referenced.idle":    reporter.CheckStatusUnused,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("statuses = %#v, want %#v", got, want)
+	}
+
+	if result.Findings[0].Topic != "produced.missing" {
+		t.Fatalf("first finding = %q, want produced.missing", result.Findings[0].Topic)
+	}
+	if result.Summary.ProducedMissingCount != 1 || result.Summary.ConsumedNoGroupCount != 1 ||
+		result.Summary.MissingInClusterCount != 1 || result.Summary.UnusedCount != 1 {
+		t.Fatalf("summary mismatch: %+v", result.Summary)
+	}
+	for _, finding := range result.Findings {
+		if finding.Topic == "consumed.idle" {
+			if !reflect.DeepEqual(finding.Directions, []string{scanner.DirectionConsume}) {
+				t.Fatalf("directions = %#v", finding.Directions)
+			}
+			if finding.References[0].Direction != scanner.DirectionConsume {
+				t.Fatalf("reference direction = %q", finding.References[0].Direction)
+			}
+		}
+		if finding.Topic == "referenced.missing" && finding.Directions != nil {
+			t.Fatalf("unknown directions should be omitted, got %#v", finding.Directions)
+		}
+	}
+}
+
+func TestFormatResolution(t *testing.T) {
+	chain := []scanner.VariableBinding{
+		{Name: "ORDERS_TOPIC", Value: "orders.${ENV}", File: ".env", Line: 2},
+		{Name: "ENV", Value: "prod", Default: true},
+	}
+
+	want := "ORDERS_TOPIC=orders.${ENV} (.env:2) -> ENV=prod (default)"
+	if got := formatResolution(chain); got != want {
+		t.Fatalf("formatResolution() = %q, want %q", got, want)
+	}
+	if got := formatResolution(nil); got != "" {
+		t.Fatalf("formatResolution(nil) = %q, want empty", got)
+	}
+}
+
+func TestBuildCheckResultPatterns(t *testing.T) {
+	metadata := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"orders.created":  {Name: "orders.created", Partitions: 1, ReplicationFactor: 1},
+			"orders.shipped":  {Name: "orders.shipped", Partitions: 1, ReplicationFactor: 1},
+			"orders":          {Name: "orders", Partitions: 1, ReplicationFactor: 1},
+			"payments.v1":     {Name: "payments.v1", Partitions: 1, ReplicationFactor: 1},
+			"__orders.system": {Name: "__orders.system", Partitions: 1, ReplicationFactor: 1, Internal: true},
+		},
+		ConsumerGroups: map[string]*kafka.ConsumerGroupInfo{
+			"billing": {GroupID: "billing", Topics: []string{"orders.created", "orders.shipped"}},
+		},
+	}
+	scanResult := &scanner.Result{
+		RepoPath: "/tmp/repo",
+		Topics: map[string]*scanner.TopicReference{
+			"orders.created": {
+				Topic:       "orders.created",
+				Occurrences: []scanner.Reference{{Topic: "orders.created", File: "src/Producer.java", Line: 9, Source: scanner.SourceRegex, Direction: scanner.DirectionProduce}},
+			},
+		},
+		Patterns: []scanner.PatternReference{
+			{Pattern: `orders\..*`, File: "src/Consumer.java", Line: 4, Column: 40, Source: scanner.SourceRegex, Direction: scanner.DirectionConsume},
+			{Pattern: `(`, File: "src/Broken.java", Line: 1, Source: scanner.SourceRegex, Direction: scanner.DirectionConsume},
+		},
+	}
+
+	result := buildCheckResult(scanResult, metadata, false, nil)
+
+	findings := make(map[string]*reporter.CheckFinding, len(result.Findings))
+	for _, finding := range result.Findings {
+		findings[finding.Topic] = finding
+	}
+
+	shipped := findings["orders.shipped"]
+	if shipped.Status != reporter.CheckStatusOK || !shipped.ReferencedInRepo {
+		t.Fatalf("orders.shipped = %#v, want OK via pattern", shipped)
+	}
+	wantRef := reporter.CheckReference{File: "src/Consumer.java", Line: 4, Column: 40, Source: scanner.SourceRegex, Direction: scanner.DirectionConsume, Pattern: `orders\..*`, Confidence: scanner.PatternConfidence}
+	if len(shipped.References) != 1 || shipped.References[0] != wantRef {
+		t.Fatalf("orders.shipped references = %#v, want %#v", shipped.References, wantRef)
+	}
+
+	if got := len(findings["orders.created"].References); got != 2 {
+		t.Fatalf("orders.created references = %d, want literal and pattern", got)
+	}
+	if len(scanResult.Topics["orders.created"].Occurrences) != 1 {
+		t.Fatalf("pattern matching must not mutate the scan result")
+	}
+	if findings["orders"].ReferencedInRepo || findings["payments.v1"].ReferencedInRepo {
+		t.Fatalf("pattern must match whole topic names only")
+	}
+	if findings["__orders.system"].ReferencedInRepo {
+		t.Fatalf("internal topics must not match patterns")
+	}
+}
+
+func TestBuildCheckResultSuppressions(t *testing.T) {
+	metadata := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"orders.replay": {Name: "orders.replay", Partitions: 1, ReplicationFactor: 1},
+			"orders.stale":  {Name: "orders.stale", Partitions: 1, ReplicationFactor: 1},
+		},
+	}
+	scanResult := &scanner.Result{
+		RepoPath: "/tmp/repo",
+		Topics:   map[string]*scanner.TopicReference{},
+		Suppressions: []scanner.Suppression{
+			{File: "config.yaml", Line: 3, Topics: []string{"orders.replay"}, Reason: "replayed manually", Topic: true},
+			{File: "config.yaml", Line: 5, Topics: []string{"orders.stale"}, Reason: "not a topic here"},
+		},
+		Suppressed: []scanner.SuppressedReference{
+			{Reference: scanner.Reference{Topic: "README.md", File: "src/app.py", Line: 7, Source: scanner.SourceRegex}, Reason: "not a topic"},
+		},
+	}
+
+	result := buildCheckResult(scanResult, metadata, false, nil)
+
+	if result.Summary.SuppressedReferences != 1 || result.Summary.SuppressedTopics != 1 {
+		t.Fatalf("summary = %#v, want 1 suppressed reference and topic", result.Summary)
+	}
+	if result.Summary.TotalFindings != 1 || len(result.Findings) != 1 || result.Findings[0].Topic != "orders.stale" {
+		t.Fatalf("findings = %#v, want only orders.stale", result.Findings)
+	}
+	want := reporter.CheckSuppression{Topic: "orders.replay", File: "config.yaml", Line: 3, Reason: "replayed manually"}
+	if len(result.Suppressed) != 2 || result.Suppressed[1] != want {
+		t.Fatalf("suppressed = %#v, want %#v last", result.Suppressed, want)
+	}
+}
+
+func TestBuildCheckResultMultiRepo(t *testing.T) {
+	metadata := &kafka.ClusterMetadata{
+		Topics: map[string]*kafka.TopicInfo{
+			"orders.created": {Name: "orders.created", Partitions: 3, ReplicationFactor: 1},
+		},
+		ConsumerGroups: map[string]*kafka.ConsumerGroupInfo{},
+	}
+	orders := &scanner.Result{
+		RepoPath:     "/tmp/orders",
+		FilesScanned: 2,
+		Topics: map[string]*scanner.TopicReference{
+			"orders.created": {Topic: "orders.created", Occurrences: []scanner.Reference{{Topic: "orders.created", File: "app.yaml", Line: 1, Source: scanner.SourceYAMLJSON}}},
+		},
+		Declarations: []scanner.TopicDeclaration{{Topic: "orders.created", File: "topics.tf", Line: 2, Source: scanner.SourceTerraform, Partitions: 6}},
+	}
+	billing := &scanner.Result{
+		RepoPath:     "/tmp/billing",
+		FilesScanned: 1,
+		Topics: map[string]*scanner.TopicReference{
+			"orders.created": {Topic: "orders.created", Occurrences: []scanner.Reference{{Topic: "orders.created", File: "app.yaml", Line: 4, Source: scanner.SourceYAMLJSON}}},
+			"billing.events": {Topic: "billing.events", Occurrences: []scanner.Reference{{Topic: "billing.events", File: "app.yaml", Line: 5, Source: scanner.SourceYAMLJSON}}},
+		},
+	}
+
+	result := buildCheckResult(scanner.MergeResults([]string{"orders", "billing"}, []*scanner.Result{orders, billing}), metadata, false, nil)
+
+	if !reflect.DeepEqual(result.Summary.Repos, []string{"orders", "billing"}) || result.Summary.FilesScanned != 3 {
+		t.Fatalf("summary = %#v", result.Summary)
+	}
+	findings := make(map[string]*reporter.CheckFinding)
+	for _, finding := range result.Findings {
+		findings[finding.Topic] = finding
+	}
+	created := findings["orders.created"]
+	if created == nil || !reflect.DeepEqual(created.Repos, []string{"billing", "orders"}) {
+		t.Fatalf("orders.created = %#v, want repos billing and orders", created)
+	}
+	if created.References[0].Repo != "billing" || created.References[1].Repo != "orders" {
+		t.Fatalf("references = %#v, want sorted by repo", created.References)
+	}
+	if missing := findings["billing.events"]; missing == nil || !reflect.DeepEqual(missing.Repos, []string{"billing"}) {
+		t.Fatalf("billing.events = %#v", missing)
+	}
+	if len(result.Drift) != 1 || result.Drift[0].Repo != "orders" {
+		t.Fatalf("drift = %#v, want partition drift declared in orders", result.Drift)
+	}
+
+	limitCheckResultToFiles(result, map[string][]string{"billing": {"app.yaml"}})
+	if len(result.Drift) != 0 || len(result.Findings) != 2 {
+		t.Fatalf("after limiting to billing/app.yaml: findings = %#v, drift = %#v", result.Findings, result.Drift)
+	}
+}
+
+func TestLimitCheckResultToFiles(t *testing.T) {
+	result := &reporter.CheckResult{
+		Summary: &reporter.CheckSummary{OKCount: 3, MissingInClusterCount: 1, UnusedCount: 1, TotalFindings: 5, DriftCount: 2, SuppressedReferences: 2, SuppressedTopics: 2},
+		Findings: []*reporter.CheckFinding{
+			{Topic: "orders.created", Status: reporter.CheckStatusOK, References: []reporter.CheckReference{{File: "svc/app.yaml"}, {File: "other.yaml"}}},
+			{Topic: "orders.shipped", Status: reporter.CheckStatusOK, References: []reporter.CheckReference{{File: "other.yaml"}}},
+			{Topic: "payments.settled", Status: reporter.CheckStatusOK, References: []reporter.CheckReference{{File: "libs/app.jar!/BOOT-INF/classes/application.yml"}}},
+			{Topic: "orders.typo", Status: reporter.CheckStatusMissingInCluster, References: []reporter.CheckReference{{File: "svc/Producer.java"}}},
+			{Topic: "stale.topic", Status: reporter.CheckStatusUnused},
+		},
+		Drift: []*reporter.DriftFinding{
+			{Topic: "orders.created", Kind: reporter.DriftKindPartitions, File: "topics.tf"},
+			{Topic: "stale.topic", Kind: reporter.DriftKindUndeclaredTopic},
+		},
+		Suppressed: []reporter.CheckSuppression{
+			{Topic: "orders.retry", File: "svc/app.yaml", Line: 3, Source: "yaml_json"},
+			{Topic: "orders.replay", File: "other.yaml", Line: 1, Source: "yaml_json"},
+			{Topic: "orders.dlq", File: "topics.tf", Line: 9},
+			{Topic: "orders.archive", File: "other.tf", Line: 2},
+		},
+	}
+
+	limitCheckResultToFiles(result, map[string][]string{"": {"svc/app.yaml", "svc/Producer.java", "topics.tf", "libs/app.jar"}})
+
+	got := make([]string, 0, len(result.Findings))
+	for _, finding := range result.Findings {
+		got = append(got, finding.Topic)
+	}
+	if want := []string{"orders.created", "payments.settled", "orders.typo"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+	summary := result.Summary
+	if summary.OKCount != 2 || summary.MissingInClusterCount != 1 || summary.UnusedCount != 0 || summary.TotalFindings != 3 {
+		t.Fatalf("summary = %#v", summary)
+	}
+	if summary.DriftCount != 1 || len(result.Drift) != 1 || summary.ChangedFiles != 4 {
+		t.Fatalf("drift = %#v, summary = %#v", result.Drift, summary)
+	}
+
+	var suppressed []string
+	for _, suppression := range result.Suppressed {
+		suppressed = append(suppressed, suppression.Topic)
+	}
+	if want := []string{"orders.retry", "orders.dlq"}; !reflect.DeepEqual(suppressed, want) {
+		t.Fatalf("suppressed = %v, want %v", suppressed, want)
+	}
+	if summary.SuppressedReferences != 1 || summary.SuppressedTopics != 1 {
+		t.Fatalf("suppressed references = %d, topics = %d, want 1 each", summary.SuppressedReferences, summary.SuppressedTopics)
+	}
+}
+
+func TestParseTemplateVars(t *testing.T) {
+	vars, err := parseTemplateVars([]string{"env=prod", " region = eu-west-1 ", "empty="})
+	if err != nil {
+		t.Fatalf("parseTemplateVars() error = %v", err)
+	}
+	want := map[string]string{"env": "prod", "region": "eu-west-1", "empty": ""}
+	if !reflect.DeepEqual(vars, want) {
+		t.Fatalf("vars = %#v, want %#v", vars, want)
+	}
+
+	for _, invalid := range []string{"env", "=prod"} {
+		if _, err := parseTemplateVars([]string{invalid}); err == nil {
+			t.Fatalf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestSuggestTopicNames(t *testing.T) {
+	clusterTopics := map[string]*kafka.TopicInfo{
+		"orders-created":     {Name: "orders-created"},
+		"order_created":      {Name: "order_created"},
+		"payments.completed": {Name: "payments.completed"},
+		"__order-created":    {Name: "__order-created", Internal: true},
+		"orders":             {Name: "orders"},
+	}
+
+	tests := []struct {
+		topic string
+		want  []string
+	}{
+		{topic: "order-created", want: []string{"order_created", "orders-created"}},
+		{topic: "payments_completed", want: []string{"payments.completed"}},
+		{topic: "invoices.v1", want: nil},
+		{topic: "{env}.orders", want: nil},
+	}
+
+	candidates := suggestionCandidates(clusterTopics)
+	for _, tt := range tests {
+		if got := suggestTopicNames(tt.topic, candidates); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("suggestTopicNames(%q) = %#v, want %#v", tt.topic, got, tt.want)
+		}
+	}
+}
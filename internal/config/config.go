@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -25,6 +26,16 @@ type Config struct {
 	Format           string
 	Timeout          time.Duration
 	HasTimeout       bool
+	Manifest         string
+	TemplateVars     map[string]string
+	MinConfidence    *float64
+	IncludePaths     []string
+	ExcludePaths     []string
+	MaxFileSize      int64
+	ScanWorkers      int
+	CacheDir         string
+	ScanArchives     *bool
+	Repos            []string
 }
 
 // Load auto-discovers and loads a config file.
@@ -156,22 +167,31 @@ func parse(data []byte) (*Config, error) {
 				return nil, fmt.Errorf("line %d: parse auth_mechanism: %w", lineNum, err)
 			}
 			cfg.AuthMechanism = strings.TrimSpace(scalar)
-		case "exclude_topics":
+		case "exclude_topics", "include_paths", "exclude_paths", "repos":
+			target := &cfg.ExcludeTopics
+			switch key {
+			case "repos":
+				target = &cfg.Repos
+			case "include_paths":
+				target = &cfg.IncludePaths
+			case "exclude_paths":
+				target = &cfg.ExcludePaths
+			}
 			if value == "" {
-				items, next, err := parseBlockList(lines, i+1)
+				items, next, err := parseBlockList(lines, i+1, key)
 				if err != nil {
 					return nil, err
 				}
-				cfg.ExcludeTopics = append(cfg.ExcludeTopics, items...)
+				*target = append(*target, items...)
 				i = next - 1
 				continue
 			}
 
 			items, err := parseInlineList(value)
 			if err != nil {
-				return nil, fmt.Errorf("line %d: parse exclude_topics: %w", lineNum, err)
+				return nil, fmt.Errorf("line %d: parse %s: %w", lineNum, key, err)
 			}
-			cfg.ExcludeTopics = append(cfg.ExcludeTopics, items...)
+			*target = append(*target, items...)
 		case "exclude_internal":
 			scalar, err := parseScalar(value)
 			if err != nil {
@@ -199,17 +219,120 @@ func parse(data []byte) (*Config, error) {
 			}
 			cfg.Timeout = duration
 			cfg.HasTimeout = true
+		case "manifest":
+			scalar, err := parseScalar(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse manifest: %w", lineNum, err)
+			}
+			cfg.Manifest = strings.TrimSpace(scalar)
+		case "max_file_size":
+			scalar, err := parseScalar(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse max_file_size: %w", lineNum, err)
+			}
+			size, err := ParseSize(scalar)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse max_file_size: %w", lineNum, err)
+			}
+			cfg.MaxFileSize = size
+		case "scan_workers":
+			scalar, err := parseScalar(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse scan_workers: %w", lineNum, err)
+			}
+			workers, err := strconv.Atoi(strings.TrimSpace(scalar))
+			if err != nil || workers < 0 {
+				return nil, fmt.Errorf("line %d: parse scan_workers: expected a non-negative integer", lineNum)
+			}
+			cfg.ScanWorkers = workers
+		case "cache_dir":
+			scalar, err := parseScalar(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse cache_dir: %w", lineNum, err)
+			}
+			cfg.CacheDir = strings.TrimSpace(scalar)
+		case "scan_archives":
+			scalar, err := parseScalar(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse scan_archives: %w", lineNum, err)
+			}
+			boolValue, err := strconv.ParseBool(strings.TrimSpace(scalar))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse scan_archives as bool: %w", lineNum, err)
+			}
+			cfg.ScanArchives = &boolValue
+		case "min_confidence":
+			scalar, err := parseScalar(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse min_confidence: %w", lineNum, err)
+			}
+			minConfidence, err := strconv.ParseFloat(strings.TrimSpace(scalar), 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse min_confidence as number: %w", lineNum, err)
+			}
+			cfg.MinConfidence = &minConfidence
+		case "template_vars":
+			if value != "" {
+				return nil, fmt.Errorf("line %d: template_vars must be a block mapping", lineNum)
+			}
+			vars, next, err := parseBlockMap(lines, i+1, key)
+			if err != nil {
+				return nil, err
+			}
+			cfg.TemplateVars = vars
+			i = next - 1
+			continue
 		default:
 			return nil, fmt.Errorf("line %d: unknown key %q", lineNum, key)
 		}
 	}
 
 	cfg.ExcludeTopics = normalizeList(cfg.ExcludeTopics)
+	cfg.IncludePaths = normalizeList(cfg.IncludePaths)
+	cfg.ExcludePaths = normalizeList(cfg.ExcludePaths)
 
 	return cfg, nil
 }
 
-func parseBlockList(lines []string, start int) ([]string, int, error) {
+// sizeUnits are the binary multipliers accepted by ParseSize.
+var sizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"kib", 1 << 10}, {"mib", 1 << 20}, {"gib", 1 << 30},
+	{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30},
+	{"k", 1 << 10}, {"m", 1 << 20}, {"g", 1 << 30},
+	{"b", 1},
+}
+
+// ParseSize parses a byte size such as 4194304, 512KiB or 4MB. Units are
+// binary: 1MB and 1MiB are both 1048576 bytes.
+func ParseSize(value string) (int64, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	if text == "" {
+		return 0, errors.New("size is empty")
+	}
+
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(text, unit.suffix) {
+			text = strings.TrimSpace(strings.TrimSuffix(text, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	number, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	if number > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("size %q is too large", value)
+	}
+	return number * multiplier, nil
+}
+
+func parseBlockList(lines []string, start int, name string) ([]string, int, error) {
 	items := make([]string, 0)
 
 	for i := start; i < len(lines); i++ {
@@ -228,17 +351,17 @@ func parseBlockList(lines []string, start int) ([]string, int, error) {
 
 		item := strings.TrimLeft(line, " \t")
 		if !strings.HasPrefix(item, "-") {
-			return nil, 0, fmt.Errorf("line %d: invalid list item for exclude_topics", lineNum)
+			return nil, 0, fmt.Errorf("line %d: invalid list item for %s", lineNum, name)
 		}
 
 		item = strings.TrimSpace(strings.TrimPrefix(item, "-"))
 		if item == "" {
-			return nil, 0, fmt.Errorf("line %d: empty list item for exclude_topics", lineNum)
+			return nil, 0, fmt.Errorf("line %d: empty list item for %s", lineNum, name)
 		}
 
 		scalar, err := parseScalar(item)
 		if err != nil {
-			return nil, 0, fmt.Errorf("line %d: parse exclude_topics item: %w", lineNum, err)
+			return nil, 0, fmt.Errorf("line %d: parse %s item: %w", lineNum, name, err)
 		}
 		items = append(items, scalar)
 	}
@@ -246,6 +369,41 @@ func parseBlockList(lines []string, start int) ([]string, int, error) {
 	return items, len(lines), nil
 }
 
+func parseBlockMap(lines []string, start int, name string) (map[string]string, int, error) {
+	items := make(map[string]string)
+
+	for i := start; i < len(lines); i++ {
+		lineNum := i + 1
+		line := strings.TrimRight(lines[i], "\r")
+		line = stripInlineComment(line)
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		// End of mapping, start of the next root-level key.
+		if line == strings.TrimLeft(line, " \t") {
+			return items, i, nil
+		}
+
+		keyPart, valuePart, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, 0, fmt.Errorf("line %d: invalid mapping entry for %s", lineNum, name)
+		}
+		key, err := parseScalar(strings.TrimSpace(keyPart))
+		if err != nil || strings.TrimSpace(key) == "" {
+			return nil, 0, fmt.Errorf("line %d: invalid key for %s", lineNum, name)
+		}
+		value, err := parseScalar(strings.TrimSpace(valuePart))
+		if err != nil {
+			return nil, 0, fmt.Errorf("line %d: parse %s value: %w", lineNum, name, err)
+		}
+		items[strings.TrimSpace(key)] = value
+	}
+
+	return items, len(lines), nil
+}
+
 func parseInlineList(value string) ([]string, error) {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -17,6 +17,21 @@ exclude_topics:
 exclude_internal: true
 format: json
 timeout: 30s
+manifest: deploy/topics.yaml
+min_confidence: 0.5
+max_file_size: 4MiB
+include_paths:
+  - "src/**"
+exclude_paths: ["**/testdata/**", "*.min.js"]
+scan_workers: 4
+cache_dir: .cache/kafkaspectre
+scan_archives: true
+repos:
+  - ../orders-service
+  - ../billing-service
+template_vars:
+  env: prod
+  region: "eu-west-1"
 `
 	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
 		t.Fatalf("write config: %v", err)
@@ -45,6 +60,55 @@ timeout: 30s
 	if !cfg.HasTimeout || cfg.Timeout != 30*time.Second {
 		t.Fatalf("timeout = %v (has=%t)", cfg.Timeout, cfg.HasTimeout)
 	}
+	if cfg.Manifest != "deploy/topics.yaml" {
+		t.Fatalf("manifest = %q", cfg.Manifest)
+	}
+	if cfg.MinConfidence == nil || *cfg.MinConfidence != 0.5 {
+		t.Fatalf("min_confidence = %v", cfg.MinConfidence)
+	}
+	if len(cfg.TemplateVars) != 2 || cfg.TemplateVars["env"] != "prod" || cfg.TemplateVars["region"] != "eu-west-1" {
+		t.Fatalf("template_vars = %#v", cfg.TemplateVars)
+	}
+	if cfg.MaxFileSize != 4<<20 {
+		t.Fatalf("max_file_size = %d", cfg.MaxFileSize)
+	}
+	if len(cfg.IncludePaths) != 1 || cfg.IncludePaths[0] != "src/**" {
+		t.Fatalf("include_paths = %#v", cfg.IncludePaths)
+	}
+	if len(cfg.ExcludePaths) != 2 || cfg.ExcludePaths[1] != "*.min.js" {
+		t.Fatalf("exclude_paths = %#v", cfg.ExcludePaths)
+	}
+	if cfg.ScanWorkers != 4 || cfg.CacheDir != ".cache/kafkaspectre" {
+		t.Fatalf("scan_workers = %d, cache_dir = %q", cfg.ScanWorkers, cfg.CacheDir)
+	}
+	if cfg.ScanArchives == nil || !*cfg.ScanArchives {
+		t.Fatalf("scan_archives = %v", cfg.ScanArchives)
+	}
+	if len(cfg.Repos) != 2 || cfg.Repos[0] != "../orders-service" || cfg.Repos[1] != "../billing-service" {
+		t.Fatalf("repos = %#v", cfg.Repos)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"1024":   1024,
+		"512KiB": 512 << 10,
+		"4mb":    4 << 20,
+		"1 G":    1 << 30,
+		"100b":   100,
+	}
+	for input, want := range tests {
+		got, err := ParseSize(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseSize(%q) = %d, %v; want %d", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"", "0", "-1MB", "lots", "9999999999G"} {
+		if _, err := ParseSize(input); err == nil {
+			t.Fatalf("ParseSize(%q) expected error", input)
+		}
+	}
 }
 
 func TestLoadFromPath_InlineList(t *testing.T) {
@@ -199,6 +263,14 @@ func TestLoadFromPath_Errors(t *testing.T) {
 	if _, err := LoadFromPath(badTimeout); err == nil {
 		t.Fatalf("expected error for invalid timeout")
 	}
+
+	inlineVars := filepath.Join(tempDir, "inline-vars.yaml")
+	if err := os.WriteFile(inlineVars, []byte("template_vars: env=prod\n"), 0o644); err != nil {
+		t.Fatalf("write template_vars config: %v", err)
+	}
+	if _, err := LoadFromPath(inlineVars); err == nil {
+		t.Fatalf("expected error for inline template_vars")
+	}
 }
 
 func samePath(left, right string) bool {
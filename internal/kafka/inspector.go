@@ -205,6 +205,7 @@ func (i *Inspector) FetchMetadata(ctx context.Context) (*ClusterMetadata, error)
 					Members:     len(described.Members),
 					Topics:      []string{}, // Will be populated from offsets
 					Lag:         make(map[string]int64),
+					Offsets:     make(map[string]map[int32]int64),
 					Coordinator: coordinator,
 				}
 			}
@@ -224,8 +225,17 @@ func (i *Inspector) FetchMetadata(ctx context.Context) (*ClusterMetadata, error)
 				topicsSet := make(map[string]bool)
 
 				// Iterate through the offset response
-				for topic := range offsets {
+				for topic, partitions := range offsets {
 					topicsSet[topic] = true
+					for partition, offset := range partitions {
+						if offset.Err != nil || offset.At < 0 {
+							continue
+						}
+						if _, ok := groupInfo.Offsets[topic]; !ok {
+							groupInfo.Offsets[topic] = make(map[int32]int64)
+						}
+						groupInfo.Offsets[topic][partition] = offset.At
+					}
 				}
 
 				// Convert topics set to list
@@ -0,0 +1,147 @@
+package kafka
+
+import (
+	"sort"
+	"strings"
+)
+
+// MirrorMaker 2 topic kinds, following the DefaultReplicationPolicy naming
+const (
+	ReplicationKindRemote      = "remote"       // <source>.<topic> mirrored from another cluster
+	ReplicationKindHeartbeats  = "heartbeats"   // heartbeats / <source>.heartbeats
+	ReplicationKindCheckpoints = "checkpoints"  // <source>.checkpoints.internal
+	ReplicationKindOffsetSyncs = "offset_syncs" // mm2-offset-syncs.<target>.internal
+	ReplicationKindInternal    = "mm2_internal" // mm2-configs/status/offsets.<cluster>.internal
+)
+
+const (
+	mm2Separator         = "."
+	mm2HeartbeatsTopic   = "heartbeats"
+	mm2CheckpointsSuffix = ".checkpoints.internal"
+	mm2InternalSuffix    = ".internal"
+	mm2OffsetSyncsPrefix = "mm2-offset-syncs."
+)
+
+var mm2ConnectInternalPrefixes = []string{"mm2-configs.", "mm2-status.", "mm2-offsets."}
+
+// ReplicationInfo describes how a topic takes part in MirrorMaker 2 replication
+type ReplicationInfo struct {
+	Kind          string
+	SourceCluster string // cluster alias the topic is attributed to
+	SourceTopic   string // upstream topic name for remote topics
+}
+
+// DetectReplication classifies MirrorMaker 2 topics in the given topic set.
+// A source cluster alias is only trusted when both <alias>.heartbeats and
+// <alias>.checkpoints.internal exist, or when it is passed in aliases, so an
+// application topic that happens to be named <x>.heartbeats does not turn
+// every <x>.* topic into a replica. Topics without a replication role are
+// omitted.
+func DetectReplication(topics map[string]*TopicInfo, aliases ...string) map[string]ReplicationInfo {
+	detected := make(map[string]ReplicationInfo)
+	heartbeats := make(map[string]struct{})
+	checkpoints := make(map[string]struct{})
+
+	for name := range topics {
+		info, ok := classifyMM2Topic(name)
+		if !ok {
+			continue
+		}
+		detected[name] = info
+		switch {
+		case info.SourceCluster == "":
+		case info.Kind == ReplicationKindHeartbeats:
+			heartbeats[info.SourceCluster] = struct{}{}
+		case info.Kind == ReplicationKindCheckpoints:
+			checkpoints[info.SourceCluster] = struct{}{}
+		}
+	}
+
+	known := make(map[string]struct{}, len(aliases))
+	for _, alias := range aliases {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			known[alias] = struct{}{}
+		}
+	}
+	for alias := range heartbeats {
+		if _, ok := checkpoints[alias]; ok {
+			known[alias] = struct{}{}
+		}
+	}
+
+	for name, info := range detected {
+		if info.Kind != ReplicationKindHeartbeats && info.Kind != ReplicationKindCheckpoints || info.SourceCluster == "" {
+			continue
+		}
+		if _, ok := known[info.SourceCluster]; !ok {
+			delete(detected, name)
+		}
+	}
+
+	for name := range topics {
+		if _, ok := detected[name]; ok {
+			continue
+		}
+		alias, sourceTopic, ok := strings.Cut(name, mm2Separator)
+		if !ok || sourceTopic == "" {
+			continue
+		}
+		if _, ok := known[alias]; !ok {
+			continue
+		}
+		detected[name] = ReplicationInfo{
+			Kind:          ReplicationKindRemote,
+			SourceCluster: alias,
+			SourceTopic:   sourceTopic,
+		}
+	}
+
+	return detected
+}
+
+// ReplicationSources returns the sorted source cluster aliases found in detected replication info
+func ReplicationSources(detected map[string]ReplicationInfo) []string {
+	set := make(map[string]struct{})
+	for _, info := range detected {
+		if info.Kind == ReplicationKindRemote || info.Kind == ReplicationKindHeartbeats || info.Kind == ReplicationKindCheckpoints {
+			if info.SourceCluster != "" {
+				set[info.SourceCluster] = struct{}{}
+			}
+		}
+	}
+
+	sources := make([]string, 0, len(set))
+	for alias := range set {
+		sources = append(sources, alias)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+func classifyMM2Topic(name string) (ReplicationInfo, bool) {
+	switch {
+	case name == mm2HeartbeatsTopic:
+		return ReplicationInfo{Kind: ReplicationKindHeartbeats}, true
+	case strings.HasSuffix(name, mm2Separator+mm2HeartbeatsTopic):
+		alias, _, _ := strings.Cut(name, mm2Separator)
+		return ReplicationInfo{Kind: ReplicationKindHeartbeats, SourceCluster: alias}, true
+	case strings.HasSuffix(name, mm2CheckpointsSuffix):
+		alias := strings.TrimSuffix(name, mm2CheckpointsSuffix)
+		if alias == "" {
+			return ReplicationInfo{}, false
+		}
+		return ReplicationInfo{Kind: ReplicationKindCheckpoints, SourceCluster: alias}, true
+	case strings.HasPrefix(name, mm2OffsetSyncsPrefix) && strings.HasSuffix(name, mm2InternalSuffix):
+		alias := strings.TrimSuffix(strings.TrimPrefix(name, mm2OffsetSyncsPrefix), mm2InternalSuffix)
+		return ReplicationInfo{Kind: ReplicationKindOffsetSyncs, SourceCluster: alias}, true
+	}
+
+	for _, prefix := range mm2ConnectInternalPrefixes {
+		if strings.HasPrefix(name, prefix) && strings.HasSuffix(name, mm2InternalSuffix) {
+			alias := strings.TrimSuffix(strings.TrimPrefix(name, prefix), mm2InternalSuffix)
+			return ReplicationInfo{Kind: ReplicationKindInternal, SourceCluster: alias}, true
+		}
+	}
+
+	return ReplicationInfo{}, false
+}
@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectReplication(t *testing.T) {
+	topics := map[string]*TopicInfo{}
+	for _, name := range []string{
+		"heartbeats",
+		"source.heartbeats",
+		"source.checkpoints.internal",
+		"mm2-offset-syncs.dr.internal",
+		"mm2-configs.source.internal",
+		"source.orders",
+		"source.orders.v2",
+		"orders",
+		"other.orders",
+	} {
+		topics[name] = &TopicInfo{Name: name}
+	}
+
+	got := DetectReplication(topics)
+	want := map[string]ReplicationInfo{
+		"heartbeats":                   {Kind: ReplicationKindHeartbeats},
+		"source.heartbeats":            {Kind: ReplicationKindHeartbeats, SourceCluster: "source"},
+		"source.checkpoints.internal":  {Kind: ReplicationKindCheckpoints, SourceCluster: "source"},
+		"mm2-offset-syncs.dr.internal": {Kind: ReplicationKindOffsetSyncs, SourceCluster: "dr"},
+		"mm2-configs.source.internal":  {Kind: ReplicationKindInternal, SourceCluster: "source"},
+		"source.orders":                {Kind: ReplicationKindRemote, SourceCluster: "source", SourceTopic: "orders"},
+		"source.orders.v2":             {Kind: ReplicationKindRemote, SourceCluster: "source", SourceTopic: "orders.v2"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DetectReplication() = %#v, want %#v", got, want)
+	}
+
+	if sources := ReplicationSources(got); !reflect.DeepEqual(sources, []string{"source"}) {
+		t.Fatalf("ReplicationSources() = %v, want [source]", sources)
+	}
+}
+
+func TestDetectReplicationWithoutMM2(t *testing.T) {
+	topics := map[string]*TopicInfo{
+		"source.orders": {Name: "source.orders"},
+		"orders":        {Name: "orders"},
+	}
+
+	if got := DetectReplication(topics); len(got) != 0 {
+		t.Fatalf("expected no replication without MM2 topics, got %#v", got)
+	}
+}
+
+func TestDetectReplicationRequiresHeartbeatsAndCheckpoints(t *testing.T) {
+	topics := map[string]*TopicInfo{}
+	for _, name := range []string{
+		"billing.heartbeats",
+		"billing.invoices",
+		"audit.checkpoints.internal",
+		"audit.events",
+	} {
+		topics[name] = &TopicInfo{Name: name}
+	}
+
+	if got := DetectReplication(topics); len(got) != 0 {
+		t.Fatalf("expected no replication from a lone heartbeats or checkpoints topic, got %#v", got)
+	}
+
+	got := DetectReplication(topics, "billing")
+	want := map[string]ReplicationInfo{
+		"billing.heartbeats": {Kind: ReplicationKindHeartbeats, SourceCluster: "billing"},
+		"billing.invoices":   {Kind: ReplicationKindRemote, SourceCluster: "billing", SourceTopic: "invoices"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DetectReplication() with alias = %#v, want %#v", got, want)
+	}
+}
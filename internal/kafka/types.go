@@ -26,7 +26,8 @@ type ConsumerGroupInfo struct {
 	State       string // Stable, Empty, Dead, etc.
 	Members     int
 	Topics      []string
-	Lag         map[string]int64 // topic -> total lag
+	Lag         map[string]int64           // topic -> total lag
+	Offsets     map[string]map[int32]int64 // topic -> partition -> committed offset
 	LastCommit  time.Time
 	Coordinator int32 // Broker ID
 }
@@ -15,14 +15,16 @@ type AuditResult struct {
 	Version   string // tool version for SpectreHub compatibility
 	Timestamp string // RFC3339 generation timestamp for SpectreHub compatibility
 
-	Summary       *AuditSummary
-	UnusedTopics  []*UnusedTopic
-	ActiveTopics  []*ActiveTopic
-	Metadata      *kafka.ClusterMetadata
-	TotalTopics   int
-	UnusedCount   int
-	ActiveCount   int
-	InternalCount int
+	Summary          *AuditSummary
+	UnusedTopics     []*UnusedTopic
+	ActiveTopics     []*ActiveTopic
+	ReplicatedTopics []*ReplicatedTopic
+	Metadata         *kafka.ClusterMetadata
+	TotalTopics      int
+	UnusedCount      int
+	ActiveCount      int
+	InternalCount    int
+	ReplicatedCount  int
 }
 
 // AuditSummary provides high-level audit insights
@@ -37,6 +39,7 @@ type AuditSummary struct {
 	UnusedTopics                 int     `json:"unused_topics"`
 	ActiveTopics                 int     `json:"active_topics"`
 	InternalTopics               int     `json:"internal_topics_excluded"`
+	ReplicatedTopics             int     `json:"replicated_topics"`
 	UnusedPercentage             float64 `json:"unused_percentage"`
 
 	// Partition Statistics
@@ -48,6 +51,9 @@ type AuditSummary struct {
 	// Consumer Group Statistics
 	TotalConsumerGroups int `json:"total_consumer_groups"`
 
+	// Replication (MirrorMaker 2)
+	ReplicationSources []string `json:"replication_sources,omitempty"`
+
 	// Risk Breakdown
 	HighRiskCount   int `json:"high_risk_count"`
 	MediumRiskCount int `json:"medium_risk_count"`
@@ -86,6 +92,19 @@ type ActiveTopic struct {
 	ConsumerCount     int      `json:"consumer_count"`
 }
 
+// ReplicatedTopic represents a topic owned by MirrorMaker 2 replication.
+// These topics have no local consumer groups by design and are reported
+// separately from unused topics.
+type ReplicatedTopic struct {
+	Name              string   `json:"name"`
+	Kind              string   `json:"kind"`
+	SourceCluster     string   `json:"source_cluster,omitempty"`
+	SourceTopic       string   `json:"source_topic,omitempty"`
+	Partitions        int      `json:"partitions"`
+	ReplicationFactor int      `json:"replication_factor"`
+	ConsumerGroups    []string `json:"consumer_groups,omitempty"`
+}
+
 // Reporter interface extended with audit capabilities
 type AuditReporter interface {
 	GenerateAudit(ctx context.Context, result *AuditResult) error
@@ -184,3 +203,16 @@ func BuildActiveTopic(topic *kafka.TopicInfo, consumers []string) *ActiveTopic {
 		ConsumerCount:     len(consumers),
 	}
 }
+
+// BuildReplicatedTopic creates a ReplicatedTopic from TopicInfo and its replication role
+func BuildReplicatedTopic(topic *kafka.TopicInfo, info kafka.ReplicationInfo, consumers []string) *ReplicatedTopic {
+	return &ReplicatedTopic{
+		Name:              topic.Name,
+		Kind:              info.Kind,
+		SourceCluster:     info.SourceCluster,
+		SourceTopic:       info.SourceTopic,
+		Partitions:        topic.Partitions,
+		ReplicationFactor: topic.ReplicationFactor,
+		ConsumerGroups:    consumers,
+	}
+}
@@ -24,13 +24,14 @@ func NewAuditJSONReporter(w io.Writer, pretty bool) *AuditJSONReporter {
 
 // AuditJSONOutput is the restructured JSON output format
 type AuditJSONOutput struct {
-	Tool            string           `json:"tool"`
-	Version         string           `json:"version"`
-	Timestamp       string           `json:"timestamp"`
-	Summary         *AuditSummary    `json:"summary"`
-	UnusedTopics    []*UnusedTopic   `json:"unused_topics"`
-	ActiveTopics    []*ActiveTopic   `json:"active_topics,omitempty"`
-	ClusterMetadata *ClusterMetadata `json:"cluster_metadata"`
+	Tool             string             `json:"tool"`
+	Version          string             `json:"version"`
+	Timestamp        string             `json:"timestamp"`
+	Summary          *AuditSummary      `json:"summary"`
+	UnusedTopics     []*UnusedTopic     `json:"unused_topics"`
+	ActiveTopics     []*ActiveTopic     `json:"active_topics,omitempty"`
+	ReplicatedTopics []*ReplicatedTopic `json:"replicated_topics,omitempty"`
+	ClusterMetadata  *ClusterMetadata   `json:"cluster_metadata"`
 }
 
 // ClusterMetadata simplified for JSON output
@@ -51,11 +52,12 @@ type BrokerInfo struct {
 func (r *AuditJSONReporter) GenerateAudit(ctx context.Context, result *AuditResult) error {
 	// Build simplified output structure
 	output := &AuditJSONOutput{
-		Tool:         result.Tool,
-		Version:      result.Version,
-		Timestamp:    result.Timestamp,
-		Summary:      result.Summary,
-		UnusedTopics: result.UnusedTopics,
+		Tool:             result.Tool,
+		Version:          result.Version,
+		Timestamp:        result.Timestamp,
+		Summary:          result.Summary,
+		UnusedTopics:     result.UnusedTopics,
+		ReplicatedTopics: result.ReplicatedTopics,
 		ClusterMetadata: &ClusterMetadata{
 			Brokers:       convertBrokers(result.Metadata.Brokers),
 			ConsumerCount: len(result.Metadata.ConsumerGroups),
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"github.com/ppiankov/kafkaspectre/internal/kafka"
 )
@@ -55,7 +56,8 @@ func (r *AuditTextReporter) GenerateAudit(ctx context.Context, result *AuditResu
 		writef("  Unused (no consumers):      %d (%.1f%%)\n",
 			result.Summary.UnusedTopics,
 			result.Summary.UnusedPercentage)
-		writef("  Internal (excluded):        %d\n\n", result.Summary.InternalTopics)
+		writef("  Internal (excluded):        %d\n", result.Summary.InternalTopics)
+		writef("  Replicated (MirrorMaker 2): %d\n\n", result.Summary.ReplicatedTopics)
 
 		// Partition statistics
 		writef("Partitions:\n")
@@ -158,6 +160,34 @@ func (r *AuditTextReporter) GenerateAudit(ctx context.Context, result *AuditResu
 		}
 	}
 
+	// Replicated Topics Section
+	if len(result.ReplicatedTopics) > 0 {
+		writef("Replicated Topics (MirrorMaker 2)\n")
+		writef("=================================\n\n")
+
+		sortedReplicated := make([]*ReplicatedTopic, len(result.ReplicatedTopics))
+		copy(sortedReplicated, result.ReplicatedTopics)
+		sort.Slice(sortedReplicated, func(i, j int) bool {
+			return sortedReplicated[i].Name < sortedReplicated[j].Name
+		})
+
+		for _, replicated := range sortedReplicated {
+			writef("[REPLICATED] %s (%s)\n", replicated.Name, replicated.Kind)
+			if replicated.SourceCluster != "" {
+				if replicated.SourceTopic != "" {
+					writef("  Source: %s (cluster %s)\n", replicated.SourceTopic, replicated.SourceCluster)
+				} else {
+					writef("  Cluster: %s\n", replicated.SourceCluster)
+				}
+			}
+			writef("  Partitions: %d, Replication: %d\n", replicated.Partitions, replicated.ReplicationFactor)
+			if len(replicated.ConsumerGroups) > 0 {
+				writef("  Consumer Groups (%d): %s\n", len(replicated.ConsumerGroups), strings.Join(replicated.ConsumerGroups, ", "))
+			}
+			writef("\n")
+		}
+	}
+
 	// Recommendations
 	if result.UnusedCount > 0 {
 		writef("Cleanup Recommendations\n")
@@ -76,6 +76,16 @@ func TestAuditTextReporterGenerateAudit(t *testing.T) {
 						ConsumerGroups:    []string{"cg-1", "cg-2", "cg-3", "cg-4", "cg-5"},
 					},
 				},
+				ReplicatedTopics: []*ReplicatedTopic{
+					{
+						Name:              "source.orders",
+						Kind:              "remote",
+						SourceCluster:     "source",
+						SourceTopic:       "orders",
+						Partitions:        6,
+						ReplicationFactor: 3,
+					},
+				},
 				UnusedCount: 2,
 				ActiveCount: 2,
 			},
@@ -92,6 +102,8 @@ func TestAuditTextReporterGenerateAudit(t *testing.T) {
 				"[ACTIVE] a-topic",
 				"[ACTIVE] z-topic",
 				"... and 2 more",
+				"[REPLICATED] source.orders (remote)",
+				"Source: orders (cluster source)",
 				"Cleanup Recommendations",
 			},
 			wantOrder: [][2]string{
@@ -10,13 +10,42 @@ const (
 	CheckStatusMissingInCluster   CheckStatus = "MISSING_IN_CLUSTER"
 	CheckStatusUnreferencedInRepo CheckStatus = "UNREFERENCED_IN_REPO"
 	CheckStatusUnused             CheckStatus = "UNUSED"
+	CheckStatusProducedMissing    CheckStatus = "PRODUCED_BUT_MISSING"
+	CheckStatusConsumedNoGroup    CheckStatus = "CONSUMED_WITHOUT_GROUP"
+)
+
+// DriftKind describes how a declared topic differs from the live cluster.
+type DriftKind string
+
+const (
+	DriftKindMissingTopic      DriftKind = "MISSING_TOPIC"
+	DriftKindUndeclaredTopic   DriftKind = "UNDECLARED_TOPIC"
+	DriftKindPartitions        DriftKind = "PARTITIONS_MISMATCH"
+	DriftKindReplicationFactor DriftKind = "REPLICATION_FACTOR_MISMATCH"
+	DriftKindConfig            DriftKind = "CONFIG_MISMATCH"
 )
 
 // CheckReference is a single repository reference to a topic.
 type CheckReference struct {
-	File   string `json:"file"`
-	Line   int    `json:"line,omitempty"`
-	Source string `json:"source"`
+	// Repo is the repository the reference was found in when several
+	// repositories are checked together.
+	Repo      string `json:"repo,omitempty"`
+	File      string `json:"file"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	Source    string `json:"source"`
+	Direction string `json:"direction,omitempty"`
+	// LiteralColumn is the column of a quoted literal spelling the topic
+	// verbatim; rename fixes only rewrite such spans.
+	LiteralColumn int `json:"-"`
+	// Pattern is the subscription regex that matched the topic, if any.
+	Pattern string `json:"pattern,omitempty"`
+	// Template is the templated name the topic was built from, if any.
+	Template string `json:"template,omitempty"`
+	// Resolution describes how a ${VAR} placeholder resolved to the topic.
+	Resolution string `json:"resolution,omitempty"`
+	// Confidence scores how likely the reference is a real topic (0-1).
+	Confidence float64 `json:"confidence,omitempty"`
 }
 
 // CheckFinding contains comparison details for one topic.
@@ -26,21 +55,61 @@ type CheckFinding struct {
 	ReferencedInRepo bool             `json:"referenced_in_repo"`
 	InCluster        bool             `json:"in_cluster"`
 	ConsumerGroups   []string         `json:"consumer_groups,omitempty"`
+	Directions       []string         `json:"directions,omitempty"`
+	Repos            []string         `json:"repos,omitempty"`
 	References       []CheckReference `json:"references,omitempty"`
+	Suggestions      []string         `json:"suggestions,omitempty"`
 	Reason           string           `json:"reason"`
 }
 
+// DriftFinding is a single difference between a declared topic and the cluster.
+type DriftFinding struct {
+	Topic    string    `json:"topic"`
+	Kind     DriftKind `json:"kind"`
+	Key      string    `json:"key,omitempty"`
+	Expected string    `json:"expected,omitempty"`
+	Actual   string    `json:"actual,omitempty"`
+	Repo     string    `json:"repo,omitempty"`
+	File     string    `json:"file,omitempty"`
+	Line     int       `json:"line,omitempty"`
+	Source   string    `json:"source,omitempty"`
+	Reason   string    `json:"reason"`
+}
+
+// CheckSuppression is a reference or topic silenced by an inline
+// kafkaspectre:ignore annotation.
+type CheckSuppression struct {
+	Topic  string `json:"topic"`
+	Repo   string `json:"repo,omitempty"`
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"`
+	Source string `json:"source,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
 // CheckSummary contains high-level check counters.
 type CheckSummary struct {
-	RepoPath                string `json:"repo_path"`
-	FilesScanned            int    `json:"files_scanned"`
-	RepoTopics              int    `json:"repo_topics"`
-	ClusterTopics           int    `json:"cluster_topics"`
-	TotalFindings           int    `json:"total_findings"`
-	OKCount                 int    `json:"ok_count"`
-	MissingInClusterCount   int    `json:"missing_in_cluster_count"`
-	UnreferencedInRepoCount int    `json:"unreferenced_in_repo_count"`
-	UnusedCount             int    `json:"unused_count"`
+	RepoPath                string   `json:"repo_path"`
+	Repos                   []string `json:"repos,omitempty"`
+	RepoRoots               []string `json:"repo_roots,omitempty"`
+	Revision                string   `json:"revision,omitempty"`
+	ChangedSince            string   `json:"changed_since,omitempty"`
+	ChangedFiles            int      `json:"changed_files,omitempty"`
+	FilesScanned            int      `json:"files_scanned"`
+	FilesSkipped            int      `json:"files_skipped,omitempty"`
+	RepoTopics              int      `json:"repo_topics"`
+	ClusterTopics           int      `json:"cluster_topics"`
+	TotalFindings           int      `json:"total_findings"`
+	OKCount                 int      `json:"ok_count"`
+	MissingInClusterCount   int      `json:"missing_in_cluster_count"`
+	UnreferencedInRepoCount int      `json:"unreferenced_in_repo_count"`
+	UnusedCount             int      `json:"unused_count"`
+	ProducedMissingCount    int      `json:"produced_but_missing_count"`
+	ConsumedNoGroupCount    int      `json:"consumed_without_group_count"`
+	DeclaredTopics          int      `json:"declared_topics,omitempty"`
+	DriftCount              int      `json:"drift_count,omitempty"`
+	SuppressedReferences    int      `json:"suppressed_references,omitempty"`
+	SuppressedTopics        int      `json:"suppressed_topics,omitempty"`
 }
 
 // CheckResult is the full output model for the check command.
@@ -50,6 +119,10 @@ type CheckResult struct {
 	Timestamp string          `json:"timestamp"`
 	Summary   *CheckSummary   `json:"summary"`
 	Findings  []*CheckFinding `json:"findings"`
+	Drift     []*DriftFinding `json:"drift,omitempty"`
+	// Suppressed lists references and topics silenced by inline
+	// annotations; they are not counted as findings.
+	Suppressed []CheckSuppression `json:"suppressed,omitempty"`
 }
 
 // CheckReporter generates check command output.
@@ -104,7 +104,7 @@ func sampleCheckResult() *CheckResult {
 				ReferencedInRepo: true,
 				InCluster:        false,
 				References: []CheckReference{
-					{File: "src/config.yaml", Line: 14, Source: "yaml_json"},
+					{File: "src/config.yaml", Line: 14, Column: 9, Source: "yaml_json"},
 				},
 				Reason: "topic is referenced in code but does not exist in cluster",
 			},
@@ -126,3 +126,156 @@ func sampleCheckResult() *CheckResult {
 		},
 	}
 }
+
+func TestCheckTextReporterGenerateCheckDrift(t *testing.T) {
+	buf := &bytes.Buffer{}
+	reporter := NewCheckTextReporter(buf)
+	result := sampleDriftCheckResult()
+
+	if err := reporter.GenerateCheck(context.Background(), result); err != nil {
+		t.Fatalf("GenerateCheck error: %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"CONFIGURATION DRIFT (2)",
+		"[PARTITIONS_MISMATCH] orders.events",
+		"Key: partitions (declared 12, cluster 6)",
+		"Declared: topics.yaml:3 (manifest)",
+		"[UNDECLARED_TOPIC] stale.topic",
+	}
+
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected output to contain %q\n%s", want, output)
+		}
+	}
+}
+
+func TestCheckTextReporterGenerateCheckDirections(t *testing.T) {
+	buf := &bytes.Buffer{}
+	reporter := NewCheckTextReporter(buf)
+	result := sampleCheckResult()
+	result.Summary.ProducedMissingCount = 1
+	result.Findings = append(result.Findings, &CheckFinding{
+		Topic:            "orders.out",
+		Status:           CheckStatusProducedMissing,
+		ReferencedInRepo: true,
+		Directions:       []string{"produce"},
+		References: []CheckReference{
+			{File: "src/Orders.java", Line: 4, Source: "source_regex", Direction: "produce"},
+		},
+		Reason: "topic is produced to in code but does not exist in cluster",
+	})
+
+	if err := reporter.GenerateCheck(context.Background(), result); err != nil {
+		t.Fatalf("GenerateCheck error: %v", err)
+	}
+
+	output := buf.String()
+	wantContains := []string{
+		"PRODUCED_BUT_MISSING:   1",
+		"[PRODUCED_BUT_MISSING] orders.out",
+		"Usage: produce",
+		"src/Orders.java:4 (source_regex, produce)",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected output to contain %q\n%s", want, output)
+		}
+	}
+	if strings.Index(output, "[PRODUCED_BUT_MISSING]") > strings.Index(output, "[MISSING_IN_CLUSTER]") {
+		t.Fatalf("expected PRODUCED_BUT_MISSING before MISSING_IN_CLUSTER")
+	}
+}
+
+func TestCheckTextReporterGenerateCheckSuggestions(t *testing.T) {
+	buf := &bytes.Buffer{}
+	reporter := NewCheckTextReporter(buf)
+	result := sampleCheckResult()
+	result.Findings = append(result.Findings, &CheckFinding{
+		Topic:            "order-created",
+		Status:           CheckStatusMissingInCluster,
+		ReferencedInRepo: true,
+		Suggestions:      []string{"orders-created", "order.created"},
+		Reason:           "topic is referenced in code but does not exist in cluster",
+	})
+
+	if err := reporter.GenerateCheck(context.Background(), result); err != nil {
+		t.Fatalf("GenerateCheck error: %v", err)
+	}
+
+	if want := "Did you mean: orders-created, order.created"; !strings.Contains(buf.String(), want) {
+		t.Fatalf("expected output to contain %q\n%s", want, buf.String())
+	}
+}
+
+func TestCheckTextReporterGenerateCheckSuppressions(t *testing.T) {
+	buf := &bytes.Buffer{}
+	reporter := NewCheckTextReporter(buf)
+	result := sampleCheckResult()
+	result.Summary.SuppressedReferences = 2
+	result.Summary.SuppressedTopics = 1
+
+	if err := reporter.GenerateCheck(context.Background(), result); err != nil {
+		t.Fatalf("GenerateCheck error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"Suppressed References:  2", "Suppressed Topics:      1"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected output to contain %q\n%s", want, output)
+		}
+	}
+}
+
+func TestCheckTextReporterGenerateCheckRepos(t *testing.T) {
+	buf := &bytes.Buffer{}
+	reporter := NewCheckTextReporter(buf)
+	result := sampleCheckResult()
+	result.Summary.Repos = []string{"orders", "billing"}
+	result.Findings = append(result.Findings, &CheckFinding{
+		Topic:            "billing.events",
+		Status:           CheckStatusMissingInCluster,
+		ReferencedInRepo: true,
+		Repos:            []string{"billing"},
+		References:       []CheckReference{{Repo: "billing", File: "app.yaml", Line: 5, Source: "yaml_json"}},
+		Reason:           "topic is referenced in code but does not exist in cluster",
+	})
+
+	if err := reporter.GenerateCheck(context.Background(), result); err != nil {
+		t.Fatalf("GenerateCheck error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"  Repos: billing", "    - [billing] app.yaml:5 (yaml_json)"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected output to contain %q\n%s", want, output)
+		}
+	}
+}
+
+func sampleDriftCheckResult() *CheckResult {
+	result := sampleCheckResult()
+	result.Summary.DeclaredTopics = 1
+	result.Summary.DriftCount = 2
+	result.Drift = []*DriftFinding{
+		{
+			Topic:    "orders.events",
+			Kind:     DriftKindPartitions,
+			Key:      "partitions",
+			Expected: "12",
+			Actual:   "6",
+			File:     "topics.yaml",
+			Line:     3,
+			Source:   "manifest",
+			Reason:   "partition count differs from declared value",
+		},
+		{
+			Topic:  "stale.topic",
+			Kind:   DriftKindUndeclaredTopic,
+			Reason: "topic exists in cluster but is not declared",
+		},
+	}
+	return result
+}
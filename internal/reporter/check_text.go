@@ -35,23 +35,46 @@ func (r *CheckTextReporter) GenerateCheck(ctx context.Context, result *CheckResu
 		summary := result.Summary
 		writef("Summary:\n")
 		writef("  Repo Path:              %s\n", summary.RepoPath)
+		if summary.Revision != "" {
+			writef("  Revision:               %s\n", summary.Revision)
+		}
+		if summary.ChangedSince != "" {
+			writef("  Changed Since:          %s (%d files)\n", summary.ChangedSince, summary.ChangedFiles)
+		}
 		writef("  Files Scanned:          %d\n", summary.FilesScanned)
+		if summary.FilesSkipped > 0 {
+			writef("  Files Skipped:          %d\n", summary.FilesSkipped)
+		}
 		writef("  Topics In Repo:         %d\n", summary.RepoTopics)
 		writef("  Topics In Cluster:      %d\n", summary.ClusterTopics)
 		writef("  OK:                     %d\n", summary.OKCount)
+		writef("  PRODUCED_BUT_MISSING:   %d\n", summary.ProducedMissingCount)
 		writef("  MISSING_IN_CLUSTER:     %d\n", summary.MissingInClusterCount)
+		writef("  CONSUMED_WITHOUT_GROUP: %d\n", summary.ConsumedNoGroupCount)
 		writef("  UNREFERENCED_IN_REPO:   %d\n", summary.UnreferencedInRepoCount)
 		writef("  UNUSED:                 %d\n", summary.UnusedCount)
+		if summary.DeclaredTopics > 0 {
+			writef("  Declared Topics:        %d\n", summary.DeclaredTopics)
+			writef("  Configuration Drift:    %d\n", summary.DriftCount)
+		}
+		if summary.SuppressedReferences > 0 || summary.SuppressedTopics > 0 {
+			writef("  Suppressed References:  %d\n", summary.SuppressedReferences)
+			writef("  Suppressed Topics:      %d\n", summary.SuppressedTopics)
+		}
 		writef("  Total Findings:         %d\n\n", summary.TotalFindings)
 	}
 
+	writeDriftFindings(writef, result.Drift)
+
 	if len(result.Findings) == 0 {
 		writef("No topic findings detected.\n")
 		return writeErr
 	}
 
 	orderedStatuses := []CheckStatus{
+		CheckStatusProducedMissing,
 		CheckStatusMissingInCluster,
+		CheckStatusConsumedNoGroup,
 		CheckStatusUnused,
 		CheckStatusUnreferencedInRepo,
 		CheckStatusOK,
@@ -75,9 +98,18 @@ func (r *CheckTextReporter) GenerateCheck(ctx context.Context, result *CheckResu
 			if finding.Reason != "" {
 				writef("  Reason: %s\n", finding.Reason)
 			}
+			if len(finding.Suggestions) > 0 {
+				writef("  Did you mean: %s\n", strings.Join(finding.Suggestions, ", "))
+			}
 			if len(finding.ConsumerGroups) > 0 {
 				writef("  Consumer Groups: %s\n", strings.Join(finding.ConsumerGroups, ", "))
 			}
+			if len(finding.Directions) > 0 {
+				writef("  Usage: %s\n", strings.Join(finding.Directions, ", "))
+			}
+			if len(finding.Repos) > 0 {
+				writef("  Repos: %s\n", strings.Join(finding.Repos, ", "))
+			}
 			if len(finding.References) > 0 {
 				writef("  References:\n")
 				limit := len(finding.References)
@@ -86,10 +118,27 @@ func (r *CheckTextReporter) GenerateCheck(ctx context.Context, result *CheckResu
 				}
 				for i := 0; i < limit; i++ {
 					ref := finding.References[i]
+					label := ref.Source
+					if ref.Direction != "" && ref.Direction != "unknown" {
+						label = fmt.Sprintf("%s, %s", ref.Source, ref.Direction)
+					}
+					if ref.Pattern != "" {
+						label = fmt.Sprintf("%s, pattern %s", label, ref.Pattern)
+					}
+					if ref.Template != "" && ref.Template != finding.Topic {
+						label = fmt.Sprintf("%s, template %s", label, ref.Template)
+					}
+					if ref.Confidence > 0 {
+						label = fmt.Sprintf("%s, confidence %.2f", label, ref.Confidence)
+					}
+					file := repoQualifiedFile(ref.Repo, ref.File)
 					if ref.Line > 0 {
-						writef("    - %s:%d (%s)\n", ref.File, ref.Line, ref.Source)
+						writef("    - %s:%d (%s)\n", file, ref.Line, label)
 					} else {
-						writef("    - %s (%s)\n", ref.File, ref.Source)
+						writef("    - %s (%s)\n", file, label)
+					}
+					if ref.Resolution != "" {
+						writef("      via %s\n", ref.Resolution)
 					}
 				}
 				if len(finding.References) > limit {
@@ -103,6 +152,50 @@ func (r *CheckTextReporter) GenerateCheck(ctx context.Context, result *CheckResu
 	return writeErr
 }
 
+func writeDriftFindings(writef func(format string, args ...any), drift []*DriftFinding) {
+	if len(drift) == 0 {
+		return
+	}
+
+	writef("CONFIGURATION DRIFT (%d)\n", len(drift))
+	writef("%s\n\n", strings.Repeat("-", len("CONFIGURATION DRIFT")+5))
+
+	for _, finding := range drift {
+		writef("[%s] %s\n", finding.Kind, finding.Topic)
+		if finding.Reason != "" {
+			writef("  Reason: %s\n", finding.Reason)
+		}
+		if finding.Key != "" {
+			writef("  Key: %s (declared %s, cluster %s)\n", finding.Key, displayDriftValue(finding.Expected), displayDriftValue(finding.Actual))
+		}
+		if finding.File != "" {
+			file := repoQualifiedFile(finding.Repo, finding.File)
+			if finding.Line > 0 {
+				writef("  Declared: %s:%d (%s)\n", file, finding.Line, finding.Source)
+			} else {
+				writef("  Declared: %s (%s)\n", file, finding.Source)
+			}
+		}
+		writef("\n")
+	}
+}
+
+// repoQualifiedFile prefixes a file with its repository in multi-repo
+// checks.
+func repoQualifiedFile(repo, file string) string {
+	if repo == "" {
+		return file
+	}
+	return fmt.Sprintf("[%s] %s", repo, file)
+}
+
+func displayDriftValue(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	return value
+}
+
 func filterCheckFindingsByStatus(findings []*CheckFinding, status CheckStatus) []*CheckFinding {
 	out := make([]*CheckFinding, 0)
 	for _, finding := range findings {
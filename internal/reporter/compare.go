@@ -0,0 +1,58 @@
+package reporter
+
+import "context"
+
+// CompareKind describes a difference between a source and target cluster.
+type CompareKind string
+
+const (
+	CompareKindMissingOnTarget   CompareKind = "MISSING_ON_TARGET"
+	CompareKindMissingOnSource   CompareKind = "MISSING_ON_SOURCE"
+	CompareKindPartitionMismatch CompareKind = "PARTITION_MISMATCH"
+	CompareKindConfigMismatch    CompareKind = "CONFIG_MISMATCH"
+	CompareKindOffsetGap         CompareKind = "OFFSET_TRANSLATION_GAP"
+)
+
+// CompareFinding is a single parity difference between two clusters.
+type CompareFinding struct {
+	Kind        CompareKind `json:"kind"`
+	Topic       string      `json:"topic"`
+	TargetTopic string      `json:"target_topic,omitempty"`
+	Group       string      `json:"group,omitempty"`
+	Key         string      `json:"key,omitempty"`
+	SourceValue string      `json:"source_value,omitempty"`
+	TargetValue string      `json:"target_value,omitempty"`
+	Reason      string      `json:"reason"`
+}
+
+// CompareSummary contains high-level compare counters.
+type CompareSummary struct {
+	SourceCluster          string `json:"source_cluster"`
+	TargetCluster          string `json:"target_cluster"`
+	SourceAlias            string `json:"source_alias,omitempty"`
+	SourceTopics           int    `json:"source_topics"`
+	TargetTopics           int    `json:"target_topics"`
+	MatchedTopics          int    `json:"matched_topics"`
+	SourceConsumerGroups   int    `json:"source_consumer_groups"`
+	TargetConsumerGroups   int    `json:"target_consumer_groups"`
+	TotalFindings          int    `json:"total_findings"`
+	MissingOnTargetCount   int    `json:"missing_on_target_count"`
+	MissingOnSourceCount   int    `json:"missing_on_source_count"`
+	PartitionMismatchCount int    `json:"partition_mismatch_count"`
+	ConfigMismatchCount    int    `json:"config_mismatch_count"`
+	OffsetGapCount         int    `json:"offset_translation_gap_count"`
+}
+
+// CompareResult is the full output model for the compare command.
+type CompareResult struct {
+	Tool      string            `json:"tool"`
+	Version   string            `json:"version"`
+	Timestamp string            `json:"timestamp"`
+	Summary   *CompareSummary   `json:"summary"`
+	Findings  []*CompareFinding `json:"findings"`
+}
+
+// CompareReporter generates compare command output.
+type CompareReporter interface {
+	GenerateCompare(ctx context.Context, result *CompareResult) error
+}
@@ -0,0 +1,40 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// CompareJSONReporter writes compare results as JSON.
+type CompareJSONReporter struct {
+	writer io.Writer
+	pretty bool
+}
+
+// NewCompareJSONReporter creates a JSON reporter for compare results.
+func NewCompareJSONReporter(w io.Writer, pretty bool) *CompareJSONReporter {
+	return &CompareJSONReporter{writer: w, pretty: pretty}
+}
+
+// GenerateCompare emits the compare result as JSON.
+func (r *CompareJSONReporter) GenerateCompare(ctx context.Context, result *CompareResult) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	if r.pretty {
+		data, err = json.MarshalIndent(result, "", "  ")
+	} else {
+		data, err = json.Marshal(result)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := r.writer.Write(data); err != nil {
+		return err
+	}
+	_, err = r.writer.Write([]byte("\n"))
+	return err
+}
@@ -0,0 +1,98 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCompareJSONReporterGenerateCompare(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := NewCompareJSONReporter(buf, false).GenerateCompare(context.Background(), sampleCompareResult()); err != nil {
+		t.Fatalf("GenerateCompare error: %v", err)
+	}
+
+	var decoded CompareResult
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &decoded); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+	if decoded.Summary == nil || decoded.Summary.TargetCluster != "dr:9092" {
+		t.Fatalf("summary mismatch: %+v", decoded.Summary)
+	}
+	if len(decoded.Findings) != 3 {
+		t.Fatalf("findings = %d, want 3", len(decoded.Findings))
+	}
+}
+
+func TestCompareTextReporterGenerateCompare(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := NewCompareTextReporter(buf).GenerateCompare(context.Background(), sampleCompareResult()); err != nil {
+		t.Fatalf("GenerateCompare error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		"Kafka Cluster Parity Report",
+		"Source Cluster:           primary:9092",
+		"[MISSING_ON_TARGET] audit -> primary.audit",
+		"[CONFIG_MISMATCH] orders -> primary.orders",
+		"Key: retention.ms",
+		"Target: 86400000",
+		"[OFFSET_TRANSLATION_GAP] orders -> primary.orders (group orders-cg)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected output to contain %q\n%s", want, output)
+		}
+	}
+}
+
+func TestSARIFReporterGenerateCompare(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := NewSARIFReporter(buf, false).GenerateCompare(context.Background(), sampleCompareResult()); err != nil {
+		t.Fatalf("GenerateCompare error: %v", err)
+	}
+
+	var output sarifReport
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &output); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+	run := output.Runs[0]
+	if len(run.Results) != 3 {
+		t.Fatalf("results = %d, want 3", len(run.Results))
+	}
+
+	levels := make(map[string]string)
+	for _, result := range run.Results {
+		levels[result.RuleID] = result.Level
+	}
+	if levels[sarifRuleIDMissingOnTarget] != "error" || levels[sarifRuleIDConfigMismatch] != "warning" || levels[sarifRuleIDOffsetGap] != "warning" {
+		t.Fatalf("unexpected levels: %v", levels)
+	}
+}
+
+func sampleCompareResult() *CompareResult {
+	return &CompareResult{
+		Tool:      "kafkaspectre",
+		Version:   "0.3.0-test",
+		Timestamp: "2026-03-01T10:00:00Z",
+		Summary: &CompareSummary{
+			SourceCluster:        "primary:9092",
+			TargetCluster:        "dr:9092",
+			SourceAlias:          "primary",
+			SourceTopics:         2,
+			TargetTopics:         1,
+			MatchedTopics:        1,
+			TotalFindings:        3,
+			MissingOnTargetCount: 1,
+			ConfigMismatchCount:  1,
+			OffsetGapCount:       1,
+		},
+		Findings: []*CompareFinding{
+			{Kind: CompareKindMissingOnTarget, Topic: "audit", TargetTopic: "primary.audit", Reason: "topic exists on source cluster but not on target cluster"},
+			{Kind: CompareKindConfigMismatch, Topic: "orders", TargetTopic: "primary.orders", Key: "retention.ms", SourceValue: "604800000", TargetValue: "86400000", Reason: "config retention.ms differs between clusters"},
+			{Kind: CompareKindOffsetGap, Topic: "orders", TargetTopic: "primary.orders", Group: "orders-cg", Reason: "consumer group has committed offsets on source but does not exist on target"},
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// CompareTextReporter writes compare results in human-readable text.
+type CompareTextReporter struct {
+	writer io.Writer
+}
+
+// NewCompareTextReporter creates a text reporter for compare results.
+func NewCompareTextReporter(w io.Writer) *CompareTextReporter {
+	return &CompareTextReporter{writer: w}
+}
+
+// GenerateCompare emits a text report for compare results.
+func (r *CompareTextReporter) GenerateCompare(ctx context.Context, result *CompareResult) error {
+	var writeErr error
+	writef := func(format string, args ...any) {
+		if writeErr != nil {
+			return
+		}
+		_, writeErr = fmt.Fprintf(r.writer, format, args...)
+	}
+
+	writef("Kafka Cluster Parity Report\n")
+	writef("===========================\n\n")
+
+	if result.Summary != nil {
+		summary := result.Summary
+		writef("Summary:\n")
+		writef("  Source Cluster:           %s\n", summary.SourceCluster)
+		writef("  Target Cluster:           %s\n", summary.TargetCluster)
+		if summary.SourceAlias != "" {
+			writef("  Source Alias:             %s\n", summary.SourceAlias)
+		}
+		writef("  Source Topics:            %d\n", summary.SourceTopics)
+		writef("  Target Topics:            %d\n", summary.TargetTopics)
+		writef("  Matched Topics:           %d\n", summary.MatchedTopics)
+		writef("  MISSING_ON_TARGET:        %d\n", summary.MissingOnTargetCount)
+		writef("  MISSING_ON_SOURCE:        %d\n", summary.MissingOnSourceCount)
+		writef("  PARTITION_MISMATCH:       %d\n", summary.PartitionMismatchCount)
+		writef("  CONFIG_MISMATCH:          %d\n", summary.ConfigMismatchCount)
+		writef("  OFFSET_TRANSLATION_GAP:   %d\n", summary.OffsetGapCount)
+		writef("  Total Findings:           %d\n\n", summary.TotalFindings)
+	}
+
+	if len(result.Findings) == 0 {
+		writef("Clusters are in parity.\n")
+		return writeErr
+	}
+
+	orderedKinds := []CompareKind{
+		CompareKindMissingOnTarget,
+		CompareKindPartitionMismatch,
+		CompareKindConfigMismatch,
+		CompareKindOffsetGap,
+		CompareKindMissingOnSource,
+	}
+
+	for _, kind := range orderedKinds {
+		group := filterCompareFindingsByKind(result.Findings, kind)
+		if len(group) == 0 {
+			continue
+		}
+
+		writef("%s (%d)\n", kind, len(group))
+		writef("%s\n\n", strings.Repeat("-", len(kind)+5))
+
+		sort.Slice(group, func(i, j int) bool {
+			if group[i].Topic != group[j].Topic {
+				return group[i].Topic < group[j].Topic
+			}
+			if group[i].Group != group[j].Group {
+				return group[i].Group < group[j].Group
+			}
+			return group[i].Key < group[j].Key
+		})
+
+		for _, finding := range group {
+			writef("[%s] %s\n", finding.Kind, compareFindingLabel(finding))
+			if finding.Reason != "" {
+				writef("  Reason: %s\n", finding.Reason)
+			}
+			if finding.Key != "" {
+				writef("  Key: %s\n", finding.Key)
+			}
+			if finding.SourceValue != "" || finding.TargetValue != "" {
+				writef("  Source: %s\n", displayCompareValue(finding.SourceValue))
+				writef("  Target: %s\n", displayCompareValue(finding.TargetValue))
+			}
+			writef("\n")
+		}
+	}
+
+	return writeErr
+}
+
+func compareFindingLabel(finding *CompareFinding) string {
+	label := finding.Topic
+	if finding.TargetTopic != "" && finding.TargetTopic != finding.Topic {
+		label = fmt.Sprintf("%s -> %s", finding.Topic, finding.TargetTopic)
+	}
+	if finding.Group != "" {
+		label = fmt.Sprintf("%s (group %s)", label, finding.Group)
+	}
+	return label
+}
+
+func displayCompareValue(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	return value
+}
+
+func filterCompareFindingsByKind(findings []*CompareFinding, kind CompareKind) []*CompareFinding {
+	out := make([]*CompareFinding, 0)
+	for _, finding := range findings {
+		if finding.Kind == kind {
+			out = append(out, finding)
+		}
+	}
+	return out
+}
@@ -25,6 +25,18 @@ const (
 	sarifRuleIDLowRiskTopic       = "kafkaspectre/LOW_RISK_TOPIC"
 	sarifRuleIDMissingInCluster   = "kafkaspectre/MISSING_IN_CLUSTER"
 	sarifRuleIDUnreferencedInRepo = "kafkaspectre/UNREFERENCED_IN_REPO"
+	sarifRuleIDProducedMissing    = "kafkaspectre/PRODUCED_BUT_MISSING"
+	sarifRuleIDConsumedNoGroup    = "kafkaspectre/CONSUMED_WITHOUT_GROUP"
+
+	sarifRuleIDMissingOnTarget   = "kafkaspectre/MISSING_ON_TARGET"
+	sarifRuleIDMissingOnSource   = "kafkaspectre/MISSING_ON_SOURCE"
+	sarifRuleIDPartitionMismatch = "kafkaspectre/PARTITION_MISMATCH"
+	sarifRuleIDConfigMismatch    = "kafkaspectre/CONFIG_MISMATCH"
+	sarifRuleIDOffsetGap         = "kafkaspectre/OFFSET_TRANSLATION_GAP"
+
+	sarifRuleIDDeclaredTopicMissing = "kafkaspectre/DECLARED_TOPIC_MISSING"
+	sarifRuleIDUndeclaredTopic      = "kafkaspectre/UNDECLARED_TOPIC"
+	sarifRuleIDTopicDrift           = "kafkaspectre/TOPIC_DRIFT"
 )
 
 // SARIFReporter writes check/audit output in SARIF 2.1.0 format.
@@ -58,6 +70,16 @@ func (r *SARIFReporter) GenerateAudit(ctx context.Context, result *AuditResult)
 	})
 }
 
+// GenerateCompare emits cluster parity findings as SARIF.
+func (r *SARIFReporter) GenerateCompare(ctx context.Context, result *CompareResult) error {
+	run := buildCompareSARIFRun(result)
+	return r.writeReport(sarifReport{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
 func (r *SARIFReporter) writeReport(report sarifReport) error {
 	var (
 		data []byte
@@ -89,9 +111,15 @@ func buildCheckSARIFRun(result *CheckResult) sarifRun {
 		buildCheckMissingInClusterRule(),
 		buildUnusedTopicRule("warning"),
 		buildCheckUnreferencedInRepoRule(),
+		buildCheckProducedMissingRule(),
+		buildCheckConsumedNoGroupRule(),
+	}
+	if len(result.Drift) > 0 {
+		rules = append(rules, buildDriftRules()...)
 	}
 
-	results := make([]sarifResult, 0, len(result.Findings))
+	baseIDs := sarifRepoBaseIDs(result.Summary)
+	results := make([]sarifResult, 0, len(result.Findings)+len(result.Drift))
 	for _, finding := range result.Findings {
 		if finding == nil {
 			continue
@@ -106,6 +134,9 @@ func buildCheckSARIFRun(result *CheckResult) sarifRun {
 		if strings.TrimSpace(message) == "" {
 			message = fmt.Sprintf("topic %q has status %s", finding.Topic, finding.Status)
 		}
+		if len(finding.Suggestions) > 0 {
+			message = fmt.Sprintf("%s (did you mean %s?)", message, strings.Join(finding.Suggestions, ", "))
+		}
 
 		entry := sarifResult{
 			RuleID: ruleID,
@@ -127,8 +158,19 @@ func buildCheckSARIFRun(result *CheckResult) sarifRun {
 		if len(finding.ConsumerGroups) > 0 {
 			entry.Properties["consumer_groups"] = finding.ConsumerGroups
 		}
+		if len(finding.Repos) > 0 {
+			entry.Properties["repos"] = finding.Repos
+		}
+		if confidence := maxReferenceConfidence(finding.References); confidence > 0 {
+			entry.Properties["confidence"] = confidence
+			entry.Rank = confidence * 100
+		}
+		if len(finding.Suggestions) > 0 {
+			entry.Properties["suggestions"] = finding.Suggestions
+			entry.Fixes = sarifRenameFixes(finding, baseIDs)
+		}
 
-		locations := sarifLocationsFromReferences(finding.References)
+		locations := sarifLocationsFromReferences(finding.References, baseIDs)
 		if len(locations) > 0 {
 			entry.Locations = locations
 		}
@@ -136,6 +178,13 @@ func buildCheckSARIFRun(result *CheckResult) sarifRun {
 		results = append(results, entry)
 	}
 
+	for _, drift := range result.Drift {
+		if drift == nil {
+			continue
+		}
+		results = append(results, buildDriftSARIFResult(drift, baseIDs))
+	}
+
 	sort.Slice(results, func(i, j int) bool {
 		if results[i].RuleID != results[j].RuleID {
 			return results[i].RuleID < results[j].RuleID
@@ -157,7 +206,19 @@ func buildCheckSARIFRun(result *CheckResult) sarifRun {
 		Results: results,
 	}
 
-	if result.Summary != nil && strings.TrimSpace(result.Summary.RepoPath) != "" {
+	// Locations are relative to %SRCROOT%, or in a multi-repo check to the
+	// root of their repository.
+	switch {
+	case result.Summary == nil:
+	case len(result.Summary.Repos) > 0 && len(result.Summary.RepoRoots) == len(result.Summary.Repos):
+		run.OriginalURIBaseIDs = make(map[string]sarifArtifactLocation, len(result.Summary.Repos))
+		for idx, repo := range result.Summary.Repos {
+			run.OriginalURIBaseIDs[baseIDs[repo]] = sarifArtifactLocation{
+				URI:         pathToFileURI(result.Summary.RepoRoots[idx]),
+				Description: &sarifMessage{Text: repo},
+			}
+		}
+	case len(result.Summary.Repos) == 0 && strings.TrimSpace(result.Summary.RepoPath) != "":
 		run.OriginalURIBaseIDs = map[string]sarifArtifactLocation{
 			sarifSrcRootBaseID: {
 				URI: pathToFileURI(result.Summary.RepoPath),
@@ -239,6 +300,131 @@ func buildAuditSARIFRun(result *AuditResult) sarifRun {
 	}
 }
 
+func buildCompareSARIFRun(result *CompareResult) sarifRun {
+	if result == nil {
+		result = &CompareResult{}
+	}
+
+	rules := []sarifRule{
+		buildCompareRule(sarifRuleIDConfigMismatch, "Topic config differs between clusters", "Topic configuration differs between source and target clusters", "A replicated topic has a different value for an important configuration key on the target cluster.", "warning"),
+		buildCompareRule(sarifRuleIDMissingOnSource, "Topic missing on source cluster", "Topic exists on target but not on source cluster", "The target cluster contains a topic that has no counterpart on the source cluster.", "note"),
+		buildCompareRule(sarifRuleIDMissingOnTarget, "Topic missing on target cluster", "Topic exists on source but not on target cluster", "The source cluster contains a topic that has no counterpart on the target cluster, so it is not protected by the DR pair.", "error"),
+		buildCompareRule(sarifRuleIDOffsetGap, "Consumer group offsets not translated", "Consumer group offsets are missing on target cluster", "A consumer group has committed offsets on the source cluster that have no translated counterpart on the target cluster.", "warning"),
+		buildCompareRule(sarifRuleIDPartitionMismatch, "Partition count differs between clusters", "Topic partition count differs between source and target clusters", "A replicated topic has a different partition count on the target cluster.", "error"),
+	}
+
+	results := make([]sarifResult, 0, len(result.Findings))
+	for _, finding := range result.Findings {
+		if finding == nil {
+			continue
+		}
+
+		ruleID, level := compareRuleMapping(finding.Kind)
+		message := finding.Reason
+		if strings.TrimSpace(message) == "" {
+			message = fmt.Sprintf("topic %q has difference %s", finding.Topic, finding.Kind)
+		}
+
+		entry := sarifResult{
+			RuleID: ruleID,
+			Level:  level,
+			Message: sarifMessage{
+				Text: fmt.Sprintf("%s: %s", compareFindingLabel(finding), message),
+			},
+			PartialFingerprints: map[string]string{
+				"compareFinding": fmt.Sprintf("%s|%s|%s|%s", finding.Topic, finding.Kind, finding.Group, finding.Key),
+			},
+			Properties: map[string]any{
+				"topic": finding.Topic,
+				"kind":  string(finding.Kind),
+			},
+		}
+		if finding.TargetTopic != "" {
+			entry.Properties["target_topic"] = finding.TargetTopic
+		}
+		if finding.Group != "" {
+			entry.Properties["group"] = finding.Group
+		}
+		if finding.Key != "" {
+			entry.Properties["key"] = finding.Key
+			entry.Properties["source_value"] = finding.SourceValue
+			entry.Properties["target_value"] = finding.TargetValue
+		}
+
+		results = append(results, entry)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].RuleID != results[j].RuleID {
+			return results[i].RuleID < results[j].RuleID
+		}
+		if sarifResultTopic(results[i]) != sarifResultTopic(results[j]) {
+			return sarifResultTopic(results[i]) < sarifResultTopic(results[j])
+		}
+		return results[i].Message.Text < results[j].Message.Text
+	})
+
+	return sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifToolInformationURI,
+				Rules:          rules,
+			},
+		},
+		Results: results,
+	}
+}
+
+func buildDriftSARIFResult(drift *DriftFinding, baseIDs map[string]string) sarifResult {
+	ruleID, level := driftRuleMapping(drift.Kind)
+	message := drift.Reason
+	if strings.TrimSpace(message) == "" {
+		message = fmt.Sprintf("topic %q has drift %s", drift.Topic, drift.Kind)
+	}
+
+	entry := sarifResult{
+		RuleID: ruleID,
+		Level:  level,
+		Message: sarifMessage{
+			Text: fmt.Sprintf("%s: %s", drift.Topic, message),
+		},
+		PartialFingerprints: map[string]string{
+			"topicDrift": fmt.Sprintf("%s|%s|%s", drift.Topic, drift.Kind, drift.Key),
+		},
+		Properties: map[string]any{
+			"topic": drift.Topic,
+			"kind":  string(drift.Kind),
+		},
+	}
+	if drift.Key != "" {
+		entry.Properties["key"] = drift.Key
+		entry.Properties["expected"] = drift.Expected
+		entry.Properties["actual"] = drift.Actual
+	}
+	if drift.Source != "" {
+		entry.Properties["source"] = drift.Source
+	}
+
+	locations := sarifLocationsFromReferences([]CheckReference{{Repo: drift.Repo, File: drift.File, Line: drift.Line, Source: drift.Source}}, baseIDs)
+	if len(locations) > 0 {
+		entry.Locations = locations
+	}
+
+	return entry
+}
+
+func driftRuleMapping(kind DriftKind) (ruleID string, level string) {
+	switch kind {
+	case DriftKindMissingTopic:
+		return sarifRuleIDDeclaredTopicMissing, "error"
+	case DriftKindUndeclaredTopic:
+		return sarifRuleIDUndeclaredTopic, "warning"
+	default:
+		return sarifRuleIDTopicDrift, "warning"
+	}
+}
+
 func checkRuleMapping(status CheckStatus) (ruleID string, level string, ok bool) {
 	switch status {
 	case CheckStatusMissingInCluster:
@@ -247,11 +433,30 @@ func checkRuleMapping(status CheckStatus) (ruleID string, level string, ok bool)
 		return sarifRuleIDUnusedTopic, "warning", true
 	case CheckStatusUnreferencedInRepo:
 		return sarifRuleIDUnreferencedInRepo, "warning", true
+	case CheckStatusProducedMissing:
+		return sarifRuleIDProducedMissing, "error", true
+	case CheckStatusConsumedNoGroup:
+		return sarifRuleIDConsumedNoGroup, "warning", true
 	default:
 		return "", "", false
 	}
 }
 
+func compareRuleMapping(kind CompareKind) (ruleID string, level string) {
+	switch kind {
+	case CompareKindMissingOnTarget:
+		return sarifRuleIDMissingOnTarget, "error"
+	case CompareKindPartitionMismatch:
+		return sarifRuleIDPartitionMismatch, "error"
+	case CompareKindConfigMismatch:
+		return sarifRuleIDConfigMismatch, "warning"
+	case CompareKindOffsetGap:
+		return sarifRuleIDOffsetGap, "warning"
+	default:
+		return sarifRuleIDMissingOnSource, "note"
+	}
+}
+
 func auditRuleMapping(risk string) (ruleID string, level string) {
 	switch strings.ToLower(strings.TrimSpace(risk)) {
 	case "high":
@@ -275,25 +480,44 @@ func sarifResultTopic(result sarifResult) string {
 	return topic
 }
 
-func sarifLocationsFromReferences(refs []CheckReference) []sarifLocation {
+// sarifRepoBaseIDs names one uriBaseId per repository of a multi-repo
+// check, keyed by repo label. Labels are --repo values such as "../svc" or
+// absolute paths, which are not valid relative URIs, so locations are
+// resolved against the repository root instead of prefixed with the label.
+func sarifRepoBaseIDs(summary *CheckSummary) map[string]string {
+	if summary == nil || len(summary.Repos) == 0 {
+		return nil
+	}
+	baseIDs := make(map[string]string, len(summary.Repos))
+	for idx, repo := range summary.Repos {
+		baseIDs[repo] = fmt.Sprintf("REPO%d", idx+1)
+	}
+	return baseIDs
+}
+
+// sarifArtifact locates a repository file, relative to the root of its
+// repository in a multi-repo check and to %SRCROOT% otherwise.
+func sarifArtifact(repo, file string, baseIDs map[string]string) sarifArtifactLocation {
+	artifact := sarifArtifactLocation{URI: filepath.ToSlash(filepath.Clean(file)), URIBaseID: sarifSrcRootBaseID}
+	if repo != "" {
+		artifact.URIBaseID = baseIDs[repo]
+	}
+	return artifact
+}
+
+func sarifLocationsFromReferences(refs []CheckReference, baseIDs map[string]string) []sarifLocation {
 	locations := make([]sarifLocation, 0, len(refs))
 	for _, ref := range refs {
 		normalized := strings.TrimSpace(ref.File)
 		if normalized == "" {
 			continue
 		}
-		normalized = filepath.ToSlash(filepath.Clean(normalized))
 
 		location := sarifLocation{
-			PhysicalLocation: sarifPhysicalLocation{
-				ArtifactLocation: sarifArtifactLocation{
-					URI:       normalized,
-					URIBaseID: sarifSrcRootBaseID,
-				},
-			},
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact(ref.Repo, normalized, baseIDs)},
 		}
 		if ref.Line > 0 {
-			location.PhysicalLocation.Region = &sarifRegion{StartLine: ref.Line}
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: ref.Line, StartColumn: ref.Column}
 		}
 
 		locations = append(locations, location)
@@ -301,6 +525,57 @@ func sarifLocationsFromReferences(refs []CheckReference) []sarifLocation {
 	return locations
 }
 
+// maxReferenceConfidence returns the best reference confidence, which is the
+// confidence that the finding's topic is really used by the repository.
+func maxReferenceConfidence(refs []CheckReference) float64 {
+	best := 0.0
+	for _, ref := range refs {
+		if ref.Confidence > best {
+			best = ref.Confidence
+		}
+	}
+	return best
+}
+
+// sarifRenameFixes proposes one fix per suggested topic name, replacing the
+// topic literal at every reference that spells it verbatim in quotes.
+// Identifiers, concatenations and references resolved through
+// placeholders, templates or patterns are skipped because rewriting their
+// span would not rename the topic.
+func sarifRenameFixes(finding *CheckFinding, baseIDs map[string]string) []sarifFix {
+	fixes := make([]sarifFix, 0, len(finding.Suggestions))
+	for _, suggestion := range finding.Suggestions {
+		changes := make([]sarifArtifactChange, 0, len(finding.References))
+		for _, ref := range finding.References {
+			if ref.Line <= 0 || ref.LiteralColumn <= 0 || strings.TrimSpace(ref.File) == "" {
+				continue
+			}
+			if ref.Resolution != "" || ref.Template != "" || ref.Pattern != "" {
+				continue
+			}
+			changes = append(changes, sarifArtifactChange{
+				ArtifactLocation: sarifArtifact(ref.Repo, ref.File, baseIDs),
+				Replacements: []sarifReplacement{{
+					DeletedRegion: sarifRegion{
+						StartLine:   ref.Line,
+						StartColumn: ref.LiteralColumn,
+						EndColumn:   ref.LiteralColumn + len(finding.Topic),
+					},
+					InsertedContent: &sarifArtifactContent{Text: suggestion},
+				}},
+			})
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		fixes = append(fixes, sarifFix{
+			Description:     sarifMessage{Text: fmt.Sprintf("Rename topic to %q", suggestion)},
+			ArtifactChanges: changes,
+		})
+	}
+	return fixes
+}
+
 func pathToFileURI(path string) string {
 	cleaned := filepath.Clean(path)
 	slashPath := filepath.ToSlash(cleaned)
@@ -370,6 +645,44 @@ func buildCheckUnreferencedInRepoRule() sarifRule {
 	}
 }
 
+func buildCheckProducedMissingRule() sarifRule {
+	return sarifRule{
+		ID:   sarifRuleIDProducedMissing,
+		Name: "Produced topic missing in cluster",
+		ShortDescription: &sarifMessage{
+			Text: "Code produces to a topic that does not exist in Kafka cluster",
+		},
+		FullDescription: &sarifMessage{
+			Text: "A producer in the repository writes to a topic that was not found in the target cluster metadata; writes fail or rely on auto-creation.",
+		},
+		DefaultConfiguration: &sarifReportingConfiguration{
+			Level: "error",
+		},
+		Properties: map[string]any{
+			"tags": []string{"kafka", "reliability", "producer"},
+		},
+	}
+}
+
+func buildCheckConsumedNoGroupRule() sarifRule {
+	return sarifRule{
+		ID:   sarifRuleIDConsumedNoGroup,
+		Name: "Consumed topic without consumer group",
+		ShortDescription: &sarifMessage{
+			Text: "Code consumes a topic that has no consumer group in Kafka cluster",
+		},
+		FullDescription: &sarifMessage{
+			Text: "A consumer in the repository reads a topic, but no consumer group is currently attached to it in the target cluster.",
+		},
+		DefaultConfiguration: &sarifReportingConfiguration{
+			Level: "warning",
+		},
+		Properties: map[string]any{
+			"tags": []string{"kafka", "reliability", "consumer"},
+		},
+	}
+}
+
 func buildHighRiskTopicRule() sarifRule {
 	return sarifRule{
 		ID:   sarifRuleIDHighRiskTopic,
@@ -427,6 +740,78 @@ func buildLowRiskTopicRule() sarifRule {
 	}
 }
 
+func buildDriftRules() []sarifRule {
+	return []sarifRule{
+		{
+			ID:   sarifRuleIDDeclaredTopicMissing,
+			Name: "Declared topic missing in cluster",
+			ShortDescription: &sarifMessage{
+				Text: "Topic is declared but does not exist in Kafka cluster",
+			},
+			FullDescription: &sarifMessage{
+				Text: "A topic declared in a manifest or infrastructure-as-code file was not found in the target cluster.",
+			},
+			DefaultConfiguration: &sarifReportingConfiguration{
+				Level: "error",
+			},
+			Properties: map[string]any{
+				"tags": []string{"kafka", "drift", "configuration"},
+			},
+		},
+		{
+			ID:   sarifRuleIDUndeclaredTopic,
+			Name: "Undeclared topic in cluster",
+			ShortDescription: &sarifMessage{
+				Text: "Topic exists in Kafka but is not declared",
+			},
+			FullDescription: &sarifMessage{
+				Text: "The cluster contains a topic that is not declared in any manifest or infrastructure-as-code file.",
+			},
+			DefaultConfiguration: &sarifReportingConfiguration{
+				Level: "warning",
+			},
+			Properties: map[string]any{
+				"tags": []string{"kafka", "drift", "inventory"},
+			},
+		},
+		{
+			ID:   sarifRuleIDTopicDrift,
+			Name: "Topic configuration drift",
+			ShortDescription: &sarifMessage{
+				Text: "Live topic settings differ from the declared state",
+			},
+			FullDescription: &sarifMessage{
+				Text: "Partition count, replication factor or a topic config value in the cluster differs from the declared definition.",
+			},
+			DefaultConfiguration: &sarifReportingConfiguration{
+				Level: "warning",
+			},
+			Properties: map[string]any{
+				"tags": []string{"kafka", "drift", "configuration"},
+			},
+		},
+	}
+}
+
+func buildCompareRule(id, name, short, full, level string) sarifRule {
+	return sarifRule{
+		ID:   id,
+		Name: name,
+		ShortDescription: &sarifMessage{
+			Text: short,
+		},
+		FullDescription: &sarifMessage{
+			Text: full,
+		},
+		DefaultConfiguration: &sarifReportingConfiguration{
+			Level: level,
+		},
+		Properties: map[string]any{
+			"tags": []string{"kafka", "disaster-recovery", "parity"},
+		},
+	}
+}
+
 type sarifReport struct {
 	Schema  string     `json:"$schema,omitempty"`
 	Version string     `json:"version"`
@@ -469,6 +854,27 @@ type sarifResult struct {
 	Locations           []sarifLocation   `json:"locations,omitempty"`
 	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
 	Properties          map[string]any    `json:"properties,omitempty"`
+	Fixes               []sarifFix        `json:"fixes,omitempty"`
+	Rank                float64           `json:"rank,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion           `json:"deletedRegion"`
+	InsertedContent *sarifArtifactContent `json:"insertedContent,omitempty"`
+}
+
+type sarifArtifactContent struct {
+	Text string `json:"text"`
 }
 
 type sarifMessage struct {
@@ -487,8 +893,12 @@ type sarifPhysicalLocation struct {
 type sarifArtifactLocation struct {
 	URI       string `json:"uri"`
 	URIBaseID string `json:"uriBaseId,omitempty"`
+	// Description is only set on originalUriBaseIds entries.
+	Description *sarifMessage `json:"description,omitempty"`
 }
 
 type sarifRegion struct {
-	StartLine int `json:"startLine,omitempty"`
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
 }
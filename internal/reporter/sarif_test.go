@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -81,6 +82,9 @@ func TestSARIFReporterGenerateCheck(t *testing.T) {
 	if location.PhysicalLocation.Region == nil || location.PhysicalLocation.Region.StartLine != 14 {
 		t.Fatalf("location line mismatch")
 	}
+	if location.PhysicalLocation.Region.StartColumn != 9 {
+		t.Fatalf("location column = %d, want 9", location.PhysicalLocation.Region.StartColumn)
+	}
 
 	if resultsByRule[sarifRuleIDUnusedTopic].Level != "warning" {
 		t.Fatalf("unused-topic level = %q, want warning", resultsByRule[sarifRuleIDUnusedTopic].Level)
@@ -137,3 +141,144 @@ func TestSARIFReporterGenerateAudit(t *testing.T) {
 		t.Fatalf("low-risk level = %q, want note", resultsByRule[sarifRuleIDLowRiskTopic].Level)
 	}
 }
+
+func TestSARIFReporterGenerateCheckDrift(t *testing.T) {
+	buf := &bytes.Buffer{}
+	reporter := NewSARIFReporter(buf, false)
+
+	if err := reporter.GenerateCheck(context.Background(), sampleDriftCheckResult()); err != nil {
+		t.Fatalf("GenerateCheck error: %v", err)
+	}
+
+	var output sarifReport
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &output); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+
+	run := output.Runs[0]
+	if len(run.Results) != 5 {
+		t.Fatalf("results = %d, want 5", len(run.Results))
+	}
+
+	var drift *sarifResult
+	for i := range run.Results {
+		if run.Results[i].RuleID == sarifRuleIDTopicDrift {
+			drift = &run.Results[i]
+		}
+	}
+	if drift == nil {
+		t.Fatalf("missing %s result", sarifRuleIDTopicDrift)
+	}
+	if len(drift.Locations) != 1 {
+		t.Fatalf("drift locations = %d, want 1", len(drift.Locations))
+	}
+	location := drift.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "topics.yaml" || location.Region == nil || location.Region.StartLine != 3 {
+		t.Fatalf("drift location = %+v", location)
+	}
+}
+
+func TestSARIFReporterGenerateCheckSuggestions(t *testing.T) {
+	buf := &bytes.Buffer{}
+	reporter := NewSARIFReporter(buf, false)
+	result := &CheckResult{
+		Summary: &CheckSummary{RepoPath: "/tmp/repo"},
+		Findings: []*CheckFinding{{
+			Topic:            "order-created",
+			Status:           CheckStatusMissingInCluster,
+			ReferencedInRepo: true,
+			References: []CheckReference{
+				{File: "main.go", Line: 12, Column: 30, LiteralColumn: 31, Source: "go_ast", Confidence: 0.9},
+				{File: "main.go", Line: 20, Column: 27, Source: "go_ast", Confidence: 0.9},
+				{File: "src/Listener.java", Line: 8, Column: 30, Source: "spring", Confidence: 0.9},
+				{File: "app.yaml", Line: 3, Source: "yaml_json", Confidence: 0.75},
+			},
+			Suggestions: []string{"orders-created"},
+			Reason:      "topic is referenced in code but does not exist in cluster",
+		}},
+	}
+
+	if err := reporter.GenerateCheck(context.Background(), result); err != nil {
+		t.Fatalf("GenerateCheck error: %v", err)
+	}
+
+	var output sarifReport
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &output); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+
+	entry := output.Runs[0].Results[0]
+	if !strings.Contains(entry.Message.Text, "did you mean orders-created?") {
+		t.Fatalf("message = %q", entry.Message.Text)
+	}
+	if entry.Properties["confidence"] != 0.9 || entry.Rank != 90 {
+		t.Fatalf("confidence = %v, rank = %v, want 0.9 and 90", entry.Properties["confidence"], entry.Rank)
+	}
+	if len(entry.Fixes) != 1 || len(entry.Fixes[0].ArtifactChanges) != 1 {
+		t.Fatalf("fixes = %#v, want one change for the verbatim literal", entry.Fixes)
+	}
+	change := entry.Fixes[0].ArtifactChanges[0]
+	want := sarifReplacement{
+		DeletedRegion:   sarifRegion{StartLine: 12, StartColumn: 31, EndColumn: 44},
+		InsertedContent: &sarifArtifactContent{Text: "orders-created"},
+	}
+	if change.ArtifactLocation.URI != "main.go" || !reflect.DeepEqual(change.Replacements, []sarifReplacement{want}) {
+		t.Fatalf("artifact change = %#v", change)
+	}
+}
+
+func TestSARIFReporterGenerateCheckMultiRepo(t *testing.T) {
+	buf := &bytes.Buffer{}
+	reporter := NewSARIFReporter(buf, false)
+	result := &CheckResult{
+		Summary: &CheckSummary{
+			RepoPath:  "../orders, /srv/billing",
+			Repos:     []string{"../orders", "/srv/billing"},
+			RepoRoots: []string{"/work/orders", "/srv/billing"},
+		},
+		Findings: []*CheckFinding{{
+			Topic:            "order-created",
+			Status:           CheckStatusMissingInCluster,
+			ReferencedInRepo: true,
+			References: []CheckReference{
+				{Repo: "../orders", File: "main.go", Line: 12, Column: 30, LiteralColumn: 31, Source: "go_ast"},
+				{Repo: "/srv/billing", File: "config/app.yaml", Line: 3, Source: "yaml_json"},
+			},
+			Suggestions: []string{"orders-created"},
+		}},
+	}
+
+	if err := reporter.GenerateCheck(context.Background(), result); err != nil {
+		t.Fatalf("GenerateCheck error: %v", err)
+	}
+
+	var output sarifReport
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &output); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+
+	run := output.Runs[0]
+	wantBaseIDs := map[string]sarifArtifactLocation{
+		"REPO1": {URI: "file:///work/orders/", Description: &sarifMessage{Text: "../orders"}},
+		"REPO2": {URI: "file:///srv/billing/", Description: &sarifMessage{Text: "/srv/billing"}},
+	}
+	if !reflect.DeepEqual(run.OriginalURIBaseIDs, wantBaseIDs) {
+		t.Fatalf("originalUriBaseIds = %#v, want %#v", run.OriginalURIBaseIDs, wantBaseIDs)
+	}
+
+	entry := run.Results[0]
+	var locations []sarifArtifactLocation
+	for _, location := range entry.Locations {
+		locations = append(locations, location.PhysicalLocation.ArtifactLocation)
+	}
+	wantLocations := []sarifArtifactLocation{
+		{URI: "main.go", URIBaseID: "REPO1"},
+		{URI: "config/app.yaml", URIBaseID: "REPO2"},
+	}
+	if !reflect.DeepEqual(locations, wantLocations) {
+		t.Fatalf("locations = %#v, want %#v", locations, wantLocations)
+	}
+	if len(entry.Fixes) != 1 || entry.Fixes[0].ArtifactChanges[0].ArtifactLocation != wantLocations[0] {
+		t.Fatalf("fixes = %#v, want one change in REPO1 main.go", entry.Fixes)
+	}
+}
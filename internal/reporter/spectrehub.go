@@ -137,6 +137,28 @@ func (r *SpectreHubReporter) GenerateCheck(_ context.Context, result *CheckResul
 		countSeverity(&envelope.Summary, severity)
 	}
 
+	for _, d := range result.Drift {
+		if d == nil {
+			continue
+		}
+		severity := driftSeverity(d.Kind)
+		finding := SpectreHubFinding{
+			ID:       string(d.Kind),
+			Severity: severity,
+			Location: d.Topic,
+			Message:  d.Reason,
+		}
+		if d.Key != "" {
+			finding.Metadata = map[string]any{
+				"key":      d.Key,
+				"expected": d.Expected,
+				"actual":   d.Actual,
+			}
+		}
+		envelope.Findings = append(envelope.Findings, finding)
+		countSeverity(&envelope.Summary, severity)
+	}
+
 	envelope.Summary.Total = len(envelope.Findings)
 	if envelope.Findings == nil {
 		envelope.Findings = []SpectreHubFinding{}
@@ -147,6 +169,17 @@ func (r *SpectreHubReporter) GenerateCheck(_ context.Context, result *CheckResul
 	return enc.Encode(envelope)
 }
 
+func driftSeverity(kind DriftKind) string {
+	switch kind {
+	case DriftKindMissingTopic:
+		return "high"
+	case DriftKindUndeclaredTopic:
+		return "low"
+	default:
+		return "medium"
+	}
+}
+
 func normalizeSeverity(risk string) string {
 	switch strings.ToLower(strings.TrimSpace(risk)) {
 	case "high":
@@ -168,6 +201,10 @@ func checkFindingMapping(status CheckStatus) (id string, severity string) {
 		return "UNUSED", "medium"
 	case CheckStatusUnreferencedInRepo:
 		return "UNREFERENCED_IN_REPO", "low"
+	case CheckStatusProducedMissing:
+		return "PRODUCED_BUT_MISSING", "high"
+	case CheckStatusConsumedNoGroup:
+		return "CONSUMED_WITHOUT_GROUP", "medium"
 	default:
 		return string(status), "info"
 	}
@@ -0,0 +1,296 @@
+package scanner
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// SourceJavaClass marks string constants read from compiled Java classes
+// inside archives.
+const SourceJavaClass = "java_class"
+
+// DefaultMaxArchiveSize is the largest archive read when Config.ScanArchives
+// is set. Entries inside are still limited by the file size limit.
+const DefaultMaxArchiveSize = 64 * 1024 * 1024
+
+// maxArchiveDepth limits how deeply archives inside archives (Spring Boot
+// BOOT-INF/lib jars, wars) are opened.
+const maxArchiveDepth = 2
+
+// maxArchiveEntries and maxArchiveTotalSize bound the work one archive on
+// disk may cause, counting the entries and decompressed bytes of nested
+// archives too, so that a zip bomb of many small or highly compressed
+// entries cannot stall the scan. The rest of the archive is skipped once
+// either is spent.
+const (
+	maxArchiveEntries   = 100_000
+	maxArchiveTotalSize = 512 * 1024 * 1024
+)
+
+// archiveBudget is what is left of maxArchiveEntries and
+// maxArchiveTotalSize while an archive is scanned.
+type archiveBudget struct {
+	entries int
+	bytes   int64
+}
+
+func newArchiveBudget() *archiveBudget {
+	return &archiveBudget{entries: maxArchiveEntries, bytes: maxArchiveTotalSize}
+}
+
+func (b *archiveBudget) spent() bool {
+	return b.entries < 0 || b.bytes < 0
+}
+
+var archiveExtensions = map[string]struct{}{
+	".jar": {},
+	".war": {},
+	".whl": {},
+	".zip": {},
+}
+
+var (
+	// classKafkaPackages mark a class as a Kafka client; string constants of
+	// other classes are ignored.
+	classKafkaPackages = []string{"org/apache/kafka/", "org/springframework/kafka/"}
+	classProducerCues  = []string{"org/apache/kafka/clients/producer/", "org/springframework/kafka/core/KafkaTemplate"}
+	classConsumerCues  = []string{"org/apache/kafka/clients/consumer/", "org/springframework/kafka/annotation/KafkaListener"}
+	// classConstantNonTopicPrefixes are Kafka client config namespaces and
+	// package names, which class constants are full of.
+	classConstantNonTopicPrefixes = []string{
+		"acks", "allow.", "auto.", "batch.", "bootstrap.", "buffer.", "client.", "compression.", "connections.",
+		"default.", "delivery.", "enable.", "fetch.", "group.", "heartbeat.", "interceptor.", "isolation.",
+		"key.", "linger.", "max.", "metadata.", "metric.", "partition.", "receive.", "reconnect.", "request.",
+		"retry.", "sasl.", "schema.registry.", "security.", "send.", "session.", "spring.", "ssl.",
+		"transactional.", "value.",
+		"com.", "io.", "java.", "javax.", "kotlin.", "net.", "org.", "scala.",
+	}
+	classConstantFileSuffixes = []string{".class", ".html", ".json", ".properties", ".txt", ".xml", ".yaml", ".yml"}
+)
+
+func isArchiveFile(filePath string) bool {
+	_, ok := archiveExtensions[strings.ToLower(path.Ext(filePath))]
+	return ok
+}
+
+// parseArchive scans the entries of a zip-based archive (jar, war, wheel,
+// zip) as if they were repository files, and string constants of compiled
+// classes that use a Kafka client. Results keep the path inside the
+// archive in their File field; scanWalked prefixes it with "<archive>!/".
+// Unreadable archives and entries are skipped rather than failing the scan,
+// and so is whatever remains once the budget is spent.
+func parseArchive(content []byte, maxEntrySize int64, budget *archiveBudget, depth int) fileResult {
+	var result fileResult
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return result
+	}
+
+	entries := make([]*zip.File, 0, len(reader.File))
+	for _, entry := range reader.File {
+		if !entry.FileInfo().IsDir() && entry.UncompressedSize64 <= uint64(maxEntrySize) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	for _, entry := range entries {
+		if budget.spent() {
+			break
+		}
+		name := strings.TrimPrefix(entry.Name, "/")
+		mode := detectScanMode(name)
+		isClass := strings.EqualFold(path.Ext(name), ".class")
+		if (mode == scanNone && !isClass) || (mode == scanArchive && depth >= maxArchiveDepth) {
+			continue
+		}
+		if budget.entries--; budget.spent() {
+			break
+		}
+		if entry.UncompressedSize64 > uint64(budget.bytes) {
+			budget.bytes = -1
+			break
+		}
+		data, err := readArchiveEntry(entry, min(maxEntrySize, budget.bytes))
+		budget.bytes -= int64(len(data))
+		if err != nil {
+			continue
+		}
+
+		var (
+			entryResult fileResult
+			prefix      string
+		)
+		switch {
+		case isClass:
+			entryResult.References = scanClassFile(data)
+		case mode == scanArchive:
+			entryResult = parseArchive(data, maxEntrySize, budget, depth+1)
+			prefix = name + "!/"
+		default:
+			entryResult, err = parseFile(walkedFile{path: name, relPath: name, mode: mode}, data)
+			if err != nil {
+				continue
+			}
+		}
+
+		file := func(inner string) string {
+			if prefix != "" {
+				return prefix + inner
+			}
+			return name
+		}
+		for _, ref := range entryResult.References {
+			ref.File = file(ref.File)
+			result.References = append(result.References, ref)
+		}
+		for _, decl := range entryResult.Declarations {
+			decl.File = file(decl.File)
+			result.Declarations = append(result.Declarations, decl)
+		}
+		for _, pattern := range entryResult.Patterns {
+			pattern.File = file(pattern.File)
+			result.Patterns = append(result.Patterns, pattern)
+		}
+		for _, suppression := range entryResult.Suppressions {
+			suppression.File = file(suppression.File)
+			result.Suppressions = append(result.Suppressions, suppression)
+		}
+	}
+	return result
+}
+
+func readArchiveEntry(entry *zip.File, maxEntrySize int64) ([]byte, error) {
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxEntrySize))
+}
+
+// scanClassFile reports topic-shaped string constants of a class that uses
+// a Kafka client. Class files carry no line for a constant, so references
+// point at the class. The direction is the class's: producer APIs only,
+// consumer APIs only, or unknown.
+func scanClassFile(data []byte) []Reference {
+	constants, names, ok := parseClassConstants(data)
+	if !ok || !containsAnyCue(names, classKafkaPackages) {
+		return nil
+	}
+
+	direction := DirectionUnknown
+	produces := containsAnyCue(names, classProducerCues)
+	consumes := containsAnyCue(names, classConsumerCues)
+	if produces && !consumes {
+		direction = DirectionProduce
+	} else if consumes && !produces {
+		direction = DirectionConsume
+	}
+
+	refs := make([]Reference, 0)
+	for _, constant := range constants {
+		if isClassConstantTopic(constant) {
+			refs = append(refs, Reference{Topic: constant, Source: SourceJavaClass, Direction: direction})
+		}
+	}
+	return refs
+}
+
+func containsAnyCue(values, cues []string) bool {
+	for _, value := range values {
+		for _, cue := range cues {
+			if strings.Contains(value, cue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isClassConstantTopic(constant string) bool {
+	if plainTokenPattern.FindString(constant) != constant || !isLikelyTopic(constant, "") {
+		return false
+	}
+	if !strings.ContainsAny(constant, ".-_") || isAllUpperUnderscore(constant) {
+		return false
+	}
+	lower := strings.ToLower(constant)
+	for _, prefix := range classConstantNonTopicPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return false
+		}
+	}
+	for _, suffix := range classConstantFileSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseClassConstants reads a class file's constant pool and returns its
+// String constants and every UTF-8 entry (class names and descriptors
+// included), in pool order.
+func parseClassConstants(data []byte) (constants []string, utf8s []string, ok bool) {
+	if len(data) < 10 || binary.BigEndian.Uint32(data) != 0xCAFEBABE {
+		return nil, nil, false
+	}
+	count := int(binary.BigEndian.Uint16(data[8:]))
+	pool := make([]string, count)
+	var stringIndexes []int
+
+	pos := 10
+	for idx := 1; idx < count; idx++ {
+		if pos >= len(data) {
+			return nil, nil, false
+		}
+		tag := data[pos]
+		pos++
+		size := 0
+		switch tag {
+		case 1: // Utf8
+			if pos+2 > len(data) {
+				return nil, nil, false
+			}
+			length := int(binary.BigEndian.Uint16(data[pos:]))
+			pos += 2
+			if pos+length > len(data) {
+				return nil, nil, false
+			}
+			pool[idx] = string(data[pos : pos+length])
+			utf8s = append(utf8s, pool[idx])
+			size = length
+		case 8: // String
+			if pos+2 > len(data) {
+				return nil, nil, false
+			}
+			stringIndexes = append(stringIndexes, int(binary.BigEndian.Uint16(data[pos:])))
+			size = 2
+		case 7, 16, 19, 20: // Class, MethodType, Module, Package
+			size = 2
+		case 15: // MethodHandle
+			size = 3
+		case 3, 4, 9, 10, 11, 12, 17, 18: // Integer, Float, refs, NameAndType, Dynamic
+			size = 4
+		case 5, 6: // Long and Double take two pool slots.
+			size = 8
+			idx++
+		default:
+			return nil, nil, false
+		}
+		pos += size
+	}
+
+	for _, idx := range stringIndexes {
+		if idx > 0 && idx < count && pool[idx] != "" {
+			constants = append(constants, pool[idx])
+		}
+	}
+	return constants, utf8s, true
+}
@@ -0,0 +1,188 @@
+package scanner
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanClassFile(t *testing.T) {
+	data := buildClassFile(t, "payments.settled", "bootstrap.servers", "org/apache/kafka/clients/producer/KafkaProducer")
+
+	refs := scanClassFile(data)
+
+	want := []Reference{{Topic: "payments.settled", Source: SourceJavaClass, Direction: DirectionProduce}}
+	if !reflect.DeepEqual(refs, want) {
+		t.Fatalf("references = %#v, want %#v", refs, want)
+	}
+
+	if refs := scanClassFile(buildClassFile(t, "payments.settled", "", "com/example/Util")); len(refs) != 0 {
+		t.Fatalf("class without Kafka client: references = %#v, want none", refs)
+	}
+	if refs := scanClassFile([]byte("not a class")); len(refs) != 0 {
+		t.Fatalf("invalid class: references = %#v, want none", refs)
+	}
+}
+
+func TestRepoScannerScanArchives(t *testing.T) {
+	repoDir := t.TempDir()
+	inner := buildZip(t, map[string][]byte{
+		"META-INF/kafka.properties": []byte("audit.topic=audit.events\n"),
+	})
+	outer := buildZip(t, map[string][]byte{
+		"BOOT-INF/classes/application.yml":          []byte("app:\n  topic: orders.created\n"),
+		"BOOT-INF/classes/com/example/Sender.class": buildClassFile(t, "payments.settled", "", "org/apache/kafka/clients/producer/KafkaProducer"),
+		"BOOT-INF/lib/inner.jar":                    inner,
+		"static/logo.png":                           []byte("orders.created"),
+	})
+	mustWriteFile(t, filepath.Join(repoDir, "libs", "app.jar"), string(outer))
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(result.Topics) != 0 || result.SkippedFiles[SkipReasonUnsupported] != 1 {
+		t.Fatalf("archives must be skipped by default: topics = %#v, skipped = %#v", result.Topics, result.SkippedFiles)
+	}
+
+	archiveScanner, err := NewRepoScannerWithConfig(Config{ScanArchives: true})
+	if err != nil {
+		t.Fatalf("NewRepoScannerWithConfig() error = %v", err)
+	}
+	result, err = archiveScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	wantFiles := map[string]string{
+		"orders.created":   "libs/app.jar!/BOOT-INF/classes/application.yml",
+		"payments.settled": "libs/app.jar!/BOOT-INF/classes/com/example/Sender.class",
+		"audit.events":     "libs/app.jar!/BOOT-INF/lib/inner.jar!/META-INF/kafka.properties",
+	}
+	if len(result.Topics) != len(wantFiles) {
+		t.Fatalf("topics = %#v", result.Topics)
+	}
+	for topic, file := range wantFiles {
+		topicRef := result.Topics[topic]
+		if topicRef == nil || len(topicRef.Occurrences) != 1 || topicRef.Occurrences[0].File != file {
+			t.Fatalf("topic %s: %#v, want one reference in %s", topic, topicRef, file)
+		}
+	}
+	ref := result.Topics["payments.settled"].Occurrences[0]
+	if ref.Source != SourceJavaClass || ref.Direction != DirectionProduce {
+		t.Fatalf("class reference = %#v", ref)
+	}
+}
+
+func TestParseArchiveBudget(t *testing.T) {
+	inner := buildZip(t, map[string][]byte{
+		"b.properties": []byte("topic=inner.b\n"),
+		"c.properties": []byte("topic=inner.c\n"),
+	})
+	archive := buildZip(t, map[string][]byte{
+		"a.properties": []byte("topic=outer.a\n"),
+		"b/inner.jar":  inner,
+		"c.properties": []byte("topic=outer.c\n"),
+	})
+
+	tests := []struct {
+		name   string
+		budget archiveBudget
+		want   []string
+	}{
+		{
+			name:   "within budget",
+			budget: *newArchiveBudget(),
+			want:   []string{"outer.a", "inner.b", "inner.c", "outer.c"},
+		},
+		{
+			name:   "nested entries count against the entry cap",
+			budget: archiveBudget{entries: 3, bytes: maxArchiveTotalSize},
+			want:   []string{"outer.a", "inner.b"},
+		},
+		{
+			name:   "decompressed bytes",
+			budget: archiveBudget{entries: maxArchiveEntries, bytes: int64(len("topic=outer.a\n") + len(inner) + len("topic=inner.b\n"))},
+			want:   []string{"outer.a", "inner.b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := tt.budget
+			result := parseArchive(archive, 1024, &budget, 1)
+
+			var topics []string
+			for _, ref := range result.References {
+				topics = append(topics, ref.Topic)
+			}
+			if !reflect.DeepEqual(topics, tt.want) {
+				t.Fatalf("topics = %v, want %v", topics, tt.want)
+			}
+		})
+	}
+}
+
+// buildClassFile assembles a minimal class file whose constant pool holds
+// the given String constants (empty ones are left out), a class reference
+// and a Long, which takes two pool slots.
+func buildClassFile(t *testing.T, constant, other, className string) []byte {
+	t.Helper()
+
+	var pool bytes.Buffer
+	count := 1
+	addUTF8 := func(value string) int {
+		pool.WriteByte(1)
+		_ = binary.Write(&pool, binary.BigEndian, uint16(len(value)))
+		pool.WriteString(value)
+		count++
+		return count - 1
+	}
+	addRef := func(tag byte, index int) {
+		pool.WriteByte(tag)
+		_ = binary.Write(&pool, binary.BigEndian, uint16(index))
+		count++
+	}
+
+	for _, value := range []string{constant, other} {
+		if value != "" {
+			addRef(8, addUTF8(value))
+		}
+	}
+	addRef(7, addUTF8(className))
+	pool.WriteByte(5)
+	_ = binary.Write(&pool, binary.BigEndian, int64(42))
+	count += 2
+
+	var data bytes.Buffer
+	_ = binary.Write(&data, binary.BigEndian, uint32(0xCAFEBABE))
+	_ = binary.Write(&data, binary.BigEndian, uint16(0))
+	_ = binary.Write(&data, binary.BigEndian, uint16(61))
+	_ = binary.Write(&data, binary.BigEndian, uint16(count))
+	data.Write(pool.Bytes())
+	return data.Bytes()
+}
+
+func buildZip(t *testing.T, entries map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range entries {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if _, err := entry.Write(content); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,279 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SourceAsyncAPI marks channels declared in AsyncAPI documents.
+const SourceAsyncAPI = "asyncapi"
+
+// asyncAPIChannel is one Kafka channel of an AsyncAPI document.
+type asyncAPIChannel struct {
+	id   string
+	node *yamlNode
+	// topic names the Kafka topic: bindings.kafka.topic, then the 3.x
+	// address, then the 2.x channel name.
+	topic     string
+	topicLine int
+}
+
+// scanAsyncAPIDocument extracts channels from an AsyncAPI 2.x or 3.x
+// document. Every channel is a declaration carrying its Kafka binding
+// (partitions, replicas, topicConfiguration) and a reference at the line
+// naming the topic; each operation on the channel adds a reference with
+// its direction. AsyncAPI 2.x describes operations from the client's side,
+// so publish means the application consumes and subscribe means it
+// produces; 3.x send and receive are the application's own actions.
+func scanAsyncAPIDocument(path string, content []byte) ([]Reference, []TopicDeclaration) {
+	if !bytes.Contains(content, []byte("asyncapi")) {
+		return nil, nil
+	}
+
+	var doc *yamlNode
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		parsed, err := parseJSONDocument(content)
+		if err != nil {
+			return nil, nil
+		}
+		doc = parsed
+	} else if docs := parseYAMLDocuments(content); len(docs) > 0 {
+		doc = docs[0]
+	}
+	version := strings.TrimSpace(doc.get("asyncapi").scalar())
+	if version == "" {
+		return nil, nil
+	}
+	v3 := !strings.HasPrefix(version, "2.")
+
+	channels := doc.get("channels")
+	if channels == nil || channels.Kind != yamlMapping {
+		return nil, nil
+	}
+
+	refs := make([]Reference, 0)
+	decls := make([]TopicDeclaration, 0)
+	for _, id := range channels.Keys {
+		channel, ok := asyncAPIKafkaChannel(id, channels.Fields[id], v3)
+		if !ok {
+			continue
+		}
+
+		refs = append(refs, Reference{Topic: channel.topic, Line: channel.topicLine, Source: SourceAsyncAPI, Direction: DirectionUnknown})
+		var operations []Reference
+		if v3 {
+			operations = asyncAPIV3Operations(doc.get("operations"), id)
+		} else {
+			operations = asyncAPIV2Operations(channel.node)
+		}
+		for _, operation := range operations {
+			operation.Topic = channel.topic
+			refs = append(refs, operation)
+		}
+
+		if IsTemplateTopic(channel.topic) {
+			continue
+		}
+		decl := TopicDeclaration{
+			Topic:  channel.topic,
+			Line:   channel.node.Line,
+			Source: SourceAsyncAPI,
+		}
+		binding := channel.node.lookup("bindings", "kafka")
+		if partitions, err := strconv.Atoi(strings.TrimSpace(binding.get("partitions").scalar())); err == nil {
+			decl.Partitions = partitions
+		}
+		if replicas, err := strconv.Atoi(strings.TrimSpace(binding.get("replicas").scalar())); err == nil {
+			decl.ReplicationFactor = replicas
+		}
+		decl.Config = asyncAPITopicConfiguration(binding.get("topicConfiguration"))
+		decls = append(decls, decl)
+	}
+
+	return refs, decls
+}
+
+// asyncAPIKafkaChannel resolves the topic a channel maps to. Channels bound
+// only to other protocols, and 3.x channels with an unknown (null) address
+// and no Kafka topic binding, are skipped.
+func asyncAPIKafkaChannel(id string, node *yamlNode, v3 bool) (asyncAPIChannel, bool) {
+	if node == nil || node.Kind != yamlMapping {
+		return asyncAPIChannel{}, false
+	}
+	bindings := node.get("bindings")
+	if bindings != nil && bindings.Kind == yamlMapping && len(bindings.Keys) > 0 && bindings.get("kafka") == nil {
+		return asyncAPIChannel{}, false
+	}
+
+	channel := asyncAPIChannel{id: id, node: node}
+	if topic := bindings.lookup("kafka", "topic"); asyncAPIScalar(topic) != "" {
+		channel.topic, channel.topicLine = asyncAPIScalar(topic), topic.Line
+	} else if !v3 {
+		channel.topic, channel.topicLine = id, node.Line
+	} else if address := node.get("address"); asyncAPIScalar(address) != "" {
+		channel.topic, channel.topicLine = asyncAPIScalar(address), address.Line
+	}
+	return channel, channel.topic != ""
+}
+
+// asyncAPIV2Operations returns the publish and subscribe operations of a
+// 2.x channel.
+func asyncAPIV2Operations(channel *yamlNode) []Reference {
+	var refs []Reference
+	for _, operation := range []struct {
+		key       string
+		direction string
+	}{
+		{key: "publish", direction: DirectionConsume},
+		{key: "subscribe", direction: DirectionProduce},
+	} {
+		if node := channel.get(operation.key); node != nil {
+			refs = append(refs, Reference{Line: node.Line, Source: SourceAsyncAPI, Direction: operation.direction})
+		}
+	}
+	return refs
+}
+
+// asyncAPIV3Operations returns the 3.x operations whose channel $ref points
+// at channelID, located at their action line.
+func asyncAPIV3Operations(operations *yamlNode, channelID string) []Reference {
+	if operations == nil || operations.Kind != yamlMapping {
+		return nil
+	}
+
+	var refs []Reference
+	for _, id := range operations.Keys {
+		operation := operations.Fields[id]
+		if asyncAPIRefTarget(operation.lookup("channel", "$ref"), "#/channels/") != channelID {
+			continue
+		}
+		action := operation.get("action")
+		direction := DirectionUnknown
+		switch strings.TrimSpace(action.scalar()) {
+		case "send":
+			direction = DirectionProduce
+		case "receive":
+			direction = DirectionConsume
+		}
+		line := operation.Line
+		if action != nil {
+			line = action.Line
+		}
+		refs = append(refs, Reference{Line: line, Source: SourceAsyncAPI, Direction: direction})
+	}
+	return refs
+}
+
+// asyncAPIRefTarget returns the unescaped JSON pointer segment after prefix
+// in a local $ref, or "".
+func asyncAPIRefTarget(ref *yamlNode, prefix string) string {
+	target, ok := strings.CutPrefix(strings.TrimSpace(ref.scalar()), prefix)
+	if !ok || strings.Contains(target, "/") {
+		return ""
+	}
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(target)
+}
+
+// asyncAPITopicConfiguration converts a Kafka channel binding's
+// topicConfiguration into topic configs; list values such as
+// cleanup.policy are joined with commas as Kafka stores them.
+func asyncAPITopicConfiguration(node *yamlNode) map[string]string {
+	if node == nil || node.Kind != yamlMapping || len(node.Keys) == 0 {
+		return nil
+	}
+	config := make(map[string]string, len(node.Keys))
+	for _, key := range node.Keys {
+		config[key] = strings.Join(node.Fields[key].strings(), ",")
+	}
+	return config
+}
+
+// asyncAPIScalar returns a trimmed scalar with YAML nulls treated as unset.
+func asyncAPIScalar(node *yamlNode) string {
+	value := strings.TrimSpace(node.scalar())
+	if value == "null" || value == "~" {
+		return ""
+	}
+	return value
+}
+
+// parseJSONDocument parses a JSON document into the same line-aware tree
+// parseYAMLDocuments produces, so JSON and YAML specs share one walker.
+// Children take the line of their key, as in YAML.
+func parseJSONDocument(content []byte) (*yamlNode, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	// The decoder only moves forward, so lines are counted from the last
+	// offset rather than from the start of the document.
+	lineNum, lastOffset := 1, 0
+	line := func() int {
+		offset := int(decoder.InputOffset())
+		if offset > lastOffset {
+			lineNum += bytes.Count(content[lastOffset:offset], []byte("\n"))
+			lastOffset = offset
+		}
+		return lineNum
+	}
+
+	var parseValue func() (*yamlNode, error)
+	parseValue = func() (*yamlNode, error) {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		node := &yamlNode{Line: line()}
+		switch value := token.(type) {
+		case json.Delim:
+			switch value {
+			case '{':
+				node.Kind = yamlMapping
+				node.Fields = make(map[string]*yamlNode)
+				for decoder.More() {
+					keyToken, err := decoder.Token()
+					if err != nil {
+						return nil, err
+					}
+					key, _ := keyToken.(string)
+					keyLine := line()
+					child, err := parseValue()
+					if err != nil {
+						return nil, err
+					}
+					child.Line = keyLine
+					if _, exists := node.Fields[key]; !exists {
+						node.Keys = append(node.Keys, key)
+					}
+					node.Fields[key] = child
+				}
+			case '[':
+				node.Kind = yamlSequence
+				for decoder.More() {
+					child, err := parseValue()
+					if err != nil {
+						return nil, err
+					}
+					node.Items = append(node.Items, child)
+				}
+			default:
+				return nil, errors.New("unexpected delimiter")
+			}
+			// Consume the closing delimiter.
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+		case string:
+			node.Value = value
+		case json.Number:
+			node.Value = value.String()
+		case bool:
+			node.Value = strconv.FormatBool(value)
+		}
+		return node, nil
+	}
+
+	return parseValue()
+}
@@ -0,0 +1,171 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanAsyncAPIDocumentV2(t *testing.T) {
+	content := []byte(`asyncapi: 2.6.0
+info:
+  title: Orders
+  version: 1.0.0
+channels:
+  orders.created:
+    subscribe:
+      message:
+        name: OrderCreated
+    bindings:
+      kafka:
+        partitions: 12
+        replicas: 3
+        topicConfiguration:
+          cleanup.policy: ["delete", "compact"]
+          retention.ms: 604800000
+  payments-in:
+    publish:
+      operationId: onPayment
+    bindings:
+      kafka:
+        topic: payments.received
+  "{env}.audit":
+    publish: {}
+  user/signedup:
+    bindings:
+      ws:
+        method: POST
+`)
+
+	refs, decls := scanAsyncAPIDocument("asyncapi.yaml", content)
+
+	wantDecls := []TopicDeclaration{
+		{
+			Topic:             "orders.created",
+			Line:              6,
+			Source:            SourceAsyncAPI,
+			Partitions:        12,
+			ReplicationFactor: 3,
+			Config:            map[string]string{"cleanup.policy": "delete,compact", "retention.ms": "604800000"},
+		},
+		{Topic: "payments.received", Line: 17, Source: SourceAsyncAPI},
+	}
+	if !reflect.DeepEqual(decls, wantDecls) {
+		t.Fatalf("declarations = %#v, want %#v", decls, wantDecls)
+	}
+
+	wantRefs := []Reference{
+		{Topic: "orders.created", Line: 6, Source: SourceAsyncAPI, Direction: DirectionUnknown},
+		{Topic: "orders.created", Line: 7, Source: SourceAsyncAPI, Direction: DirectionProduce},
+		{Topic: "payments.received", Line: 22, Source: SourceAsyncAPI, Direction: DirectionUnknown},
+		{Topic: "payments.received", Line: 18, Source: SourceAsyncAPI, Direction: DirectionConsume},
+		{Topic: "{env}.audit", Line: 23, Source: SourceAsyncAPI, Direction: DirectionUnknown},
+		{Topic: "{env}.audit", Line: 24, Source: SourceAsyncAPI, Direction: DirectionConsume},
+	}
+	if !reflect.DeepEqual(refs, wantRefs) {
+		t.Fatalf("references = %#v, want %#v", refs, wantRefs)
+	}
+}
+
+func TestScanAsyncAPIDocumentV3JSON(t *testing.T) {
+	content := []byte(`{
+  "asyncapi": "3.0.0",
+  "info": {"title": "Billing", "version": "1.0.0"},
+  "channels": {
+    "invoices": {
+      "address": "billing.invoices",
+      "bindings": {
+        "kafka": {"partitions": 6, "topicConfiguration": {"retention.ms": 86400000}}
+      }
+    },
+    "dynamic": {
+      "address": null
+    }
+  },
+  "operations": {
+    "sendInvoice": {
+      "action": "send",
+      "channel": {"$ref": "#/channels/invoices"}
+    },
+    "auditInvoice": {
+      "action": "receive",
+      "channel": {"$ref": "#/channels/invoices"}
+    }
+  }
+}
+`)
+
+	refs, decls := scanAsyncAPIDocument("billing.json", content)
+
+	wantDecls := []TopicDeclaration{
+		{Topic: "billing.invoices", Line: 5, Source: SourceAsyncAPI, Partitions: 6, Config: map[string]string{"retention.ms": "86400000"}},
+	}
+	if !reflect.DeepEqual(decls, wantDecls) {
+		t.Fatalf("declarations = %#v, want %#v", decls, wantDecls)
+	}
+
+	wantRefs := []Reference{
+		{Topic: "billing.invoices", Line: 6, Source: SourceAsyncAPI, Direction: DirectionUnknown},
+		{Topic: "billing.invoices", Line: 17, Source: SourceAsyncAPI, Direction: DirectionProduce},
+		{Topic: "billing.invoices", Line: 21, Source: SourceAsyncAPI, Direction: DirectionConsume},
+	}
+	if !reflect.DeepEqual(refs, wantRefs) {
+		t.Fatalf("references = %#v, want %#v", refs, wantRefs)
+	}
+}
+
+func TestScanAsyncAPIDocumentIgnoresOtherFiles(t *testing.T) {
+	for name, content := range map[string]string{
+		"openapi.yaml": "openapi: 3.0.0\npaths: {}\n",
+		"notes.yaml":   "asyncapi_notes: see wiki\nchannels:\n  orders: {}\n",
+		"broken.json":  `{"asyncapi": "3.0.0", "channels": {`,
+	} {
+		if refs, decls := scanAsyncAPIDocument(name, []byte(content)); len(refs) != 0 || len(decls) != 0 {
+			t.Fatalf("%s: refs = %#v, decls = %#v, want none", name, refs, decls)
+		}
+	}
+}
+
+func TestRepoScannerScanAsyncAPI(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "docs", "asyncapi.yaml"), `asyncapi: 3.0.0
+channels:
+  shipments:
+    address: shipments.v1
+    bindings:
+      kafka:
+        topic: shipments.v1
+        replicas: 2
+operations:
+  ship:
+    action: send
+    channel:
+      $ref: '#/channels/shipments'
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	wantDecls := []TopicDeclaration{{Topic: "shipments.v1", File: "docs/asyncapi.yaml", Line: 3, Source: SourceAsyncAPI, ReplicationFactor: 2}}
+	if !reflect.DeepEqual(result.Declarations, wantDecls) {
+		t.Fatalf("declarations = %#v, want %#v", result.Declarations, wantDecls)
+	}
+
+	topicRef := result.Topics["shipments.v1"]
+	if topicRef == nil {
+		t.Fatalf("expected shipments.v1 reference, got %#v", result.Topics)
+	}
+	var lines []int
+	for _, ref := range topicRef.Occurrences {
+		if ref.Source != SourceAsyncAPI {
+			t.Fatalf("binding topic line must be attributed to asyncapi only, got %#v", ref)
+		}
+		lines = append(lines, ref.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{7, 11}) {
+		t.Fatalf("reference lines = %v, want [7 11]", lines)
+	}
+}
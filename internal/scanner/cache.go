@@ -0,0 +1,200 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// scanCacheVersion is bumped whenever cached scan output changes, so
+// caches written by older builds are discarded.
+const scanCacheVersion = 6
+
+// fileResult is what one file contributes to a scan on its own. It is the
+// unit stored in the scan cache, keyed by path and content hash.
+type fileResult struct {
+	Hash         string             `json:"hash"`
+	References   []Reference        `json:"references,omitempty"`
+	Declarations []TopicDeclaration `json:"declarations,omitempty"`
+	Patterns     []PatternReference `json:"patterns,omitempty"`
+	Suppressions []Suppression      `json:"suppressions,omitempty"`
+	Variables    []VariableBinding  `json:"variables,omitempty"`
+}
+
+// passResult is what a cross-file pass (Go packages, Spring) contributes to
+// a scan. It is stored in the scan cache under a key hashed from the paths
+// and content hashes of the pass's input files, plus the hashes of go.mod
+// files read along the way ("" for missing ones).
+type passResult struct {
+	Key        string                 `json:"key"`
+	Modules    map[string]string      `json:"modules,omitempty"`
+	References map[string][]Reference `json:"references,omitempty"`
+	Patterns   []PatternReference     `json:"patterns,omitempty"`
+}
+
+// scannedFile is a walked file after reading and per-file parsing. Content
+// is not kept; cross-file passes read their files again when their cached
+// result is out of date.
+type scannedFile struct {
+	walkedFile
+	result         fileResult
+	cached         bool
+	springProperty bool
+	springSource   bool
+}
+
+// scanCache holds per-file and per-pass results from the previous scan of
+// a repo. A nil cache is valid and disables caching.
+type scanCache struct {
+	path         string
+	repoPath     string
+	maxFileSize  int64
+	scanArchives bool
+	entries      map[string]fileResult
+	passes       map[string]*passResult
+}
+
+type scanCacheFile struct {
+	Version      int                    `json:"version"`
+	RepoPath     string                 `json:"repo_path"`
+	MaxFileSize  int64                  `json:"max_file_size"`
+	ScanArchives bool                   `json:"scan_archives,omitempty"`
+	Files        map[string]fileResult  `json:"files"`
+	Passes       map[string]*passResult `json:"passes,omitempty"`
+}
+
+// loadScanCache opens the cache for absRepoPath under dir. A missing,
+// unreadable or outdated cache file, or one written with a different file
+// size limit or archive setting, starts an empty cache.
+func loadScanCache(dir, absRepoPath string, maxFileSize int64, scanArchives bool) *scanCache {
+	if dir == "" {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte(absRepoPath))
+	cache := &scanCache{
+		path:         filepath.Join(dir, "scan-"+hex.EncodeToString(sum[:8])+".json"),
+		repoPath:     absRepoPath,
+		maxFileSize:  maxFileSize,
+		scanArchives: scanArchives,
+		passes:       make(map[string]*passResult),
+	}
+	data, err := os.ReadFile(cache.path)
+	if err != nil {
+		return cache
+	}
+	var stored scanCacheFile
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return cache
+	}
+	if stored.Version != scanCacheVersion || stored.RepoPath != absRepoPath ||
+		stored.MaxFileSize != maxFileSize || stored.ScanArchives != scanArchives {
+		return cache
+	}
+	cache.entries = stored.Files
+	if stored.Passes != nil {
+		cache.passes = stored.Passes
+	}
+	return cache
+}
+
+func (c *scanCache) lookup(relPath, hash string) (fileResult, bool) {
+	if c == nil {
+		return fileResult{}, false
+	}
+	entry, ok := c.entries[relPath]
+	if !ok || entry.Hash != hash {
+		return fileResult{}, false
+	}
+	return entry, true
+}
+
+// lookupPass returns the cached result of the named pass when it was
+// computed from the same input files and the go.mod files it read are
+// unchanged.
+func (c *scanCache) lookupPass(name, key string, read readFunc) (*passResult, bool) {
+	if c == nil {
+		return nil, false
+	}
+	pass, ok := c.passes[name]
+	if !ok || pass.Key != key {
+		return nil, false
+	}
+	for path, hash := range pass.Modules {
+		if readHash(read, path) != hash {
+			return nil, false
+		}
+	}
+	return pass, true
+}
+
+// storePass records the result of the named pass for save.
+func (c *scanCache) storePass(name string, pass *passResult) {
+	if c == nil {
+		return
+	}
+	c.passes[name] = pass
+}
+
+// save replaces the cache with the results of this scan, dropping entries
+// for files that no longer exist.
+func (c *scanCache) save(files []scannedFile) error {
+	if c == nil {
+		return nil
+	}
+
+	stored := scanCacheFile{
+		Version:      scanCacheVersion,
+		RepoPath:     c.repoPath,
+		MaxFileSize:  c.maxFileSize,
+		ScanArchives: c.scanArchives,
+		Files:        make(map[string]fileResult, len(files)),
+		Passes:       c.passes,
+	}
+	for _, file := range files {
+		stored.Files[file.relPath] = file.result
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("encode scan cache: %w", err)
+	}
+
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create scan cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".scan-*.tmp")
+	if err != nil {
+		return fmt.Errorf("write scan cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write scan cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write scan cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("write scan cache: %w", err)
+	}
+	return nil
+}
+
+// readHash is the content hash of a file read with read, or "" when it
+// cannot be read.
+func readHash(read readFunc, path string) string {
+	content, err := read(path)
+	if err != nil {
+		return ""
+	}
+	return contentHash(content)
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,187 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanCacheReusesUnchangedFiles(t *testing.T) {
+	repoDir := t.TempDir()
+	cacheDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "app.yaml"), "topic: ${ORDERS_TOPIC}\n")
+	mustWriteFile(t, filepath.Join(repoDir, ".env"), "ORDERS_TOPIC=orders.created\n")
+	mustWriteFile(t, filepath.Join(repoDir, "Consumer.java"), `class Consumer {
+    void run() { consumer.subscribe(List.of("payments.settled")); }
+}
+`)
+
+	repoScanner, err := NewRepoScannerWithConfig(Config{CacheDir: cacheDir})
+	if err != nil {
+		t.Fatalf("NewRepoScannerWithConfig() error = %v", err)
+	}
+	first, err := repoScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("first Scan() error = %v", err)
+	}
+	if first.CachedFiles != 0 {
+		t.Fatalf("first scan CachedFiles = %d, want 0", first.CachedFiles)
+	}
+
+	second, err := repoScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("second Scan() error = %v", err)
+	}
+	if second.CachedFiles != 3 {
+		t.Fatalf("second scan CachedFiles = %d, want 3", second.CachedFiles)
+	}
+	second.CachedFiles = 0
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("cached scan differs:\nfirst  %#v\nsecond %#v", first.Topics, second.Topics)
+	}
+
+	mustWriteFile(t, filepath.Join(repoDir, ".env"), "ORDERS_TOPIC=orders.placed\n")
+	third, err := repoScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("third Scan() error = %v", err)
+	}
+	if third.CachedFiles != 2 {
+		t.Fatalf("third scan CachedFiles = %d, want 2", third.CachedFiles)
+	}
+	if _, ok := third.Topics["orders.placed"]; !ok {
+		t.Fatalf("expected changed .env to be rescanned, got %#v", third.Topics)
+	}
+}
+
+func TestScanCacheReusesCrossFilePasses(t *testing.T) {
+	repoDir := t.TempDir()
+	cacheDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "go.mod"), "module example.com/shop\n")
+	mustWriteFile(t, filepath.Join(repoDir, "names", "names.go"), `package names
+
+const Orders = "shop." + "orders.v1"
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "main.go"), `package main
+
+import (
+	"example.com/shop/names"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+var _ = kgo.ConsumeTopics(names.Orders)
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "src", "main", "resources", "application.properties"), "app.topic=shipments.v1\n")
+	mustWriteFile(t, filepath.Join(repoDir, "src", "main", "java", "Listener.java"), `class Listener {
+    @KafkaListener(topics = "${app.topic}")
+    void on(String value) {}
+}
+`)
+
+	repoScanner, err := NewRepoScannerWithConfig(Config{CacheDir: cacheDir})
+	if err != nil {
+		t.Fatalf("NewRepoScannerWithConfig() error = %v", err)
+	}
+	first, err := repoScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("first Scan() error = %v", err)
+	}
+	if !hasSource(first, "shop.orders.v1", SourceGoAST) || !hasSource(first, "shipments.v1", SourceSpring) {
+		t.Fatalf("first scan topics = %#v", first.Topics)
+	}
+	cache := loadScanCache(cacheDir, first.RepoPath, DefaultMaxFileSize, false)
+	if cache.passes["go"] == nil || cache.passes["spring"] == nil {
+		t.Fatalf("cached passes = %#v, want go and spring", cache.passes)
+	}
+
+	second, err := repoScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("second Scan() error = %v", err)
+	}
+	second.CachedFiles = first.CachedFiles
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("cached scan differs:\nfirst  %#v\nsecond %#v", first.Topics, second.Topics)
+	}
+
+	// The module path decides whether names.Orders resolves, so a changed
+	// go.mod invalidates the Go pass even though no Go file changed.
+	mustWriteFile(t, filepath.Join(repoDir, "go.mod"), "module example.com/other\n")
+	third, err := repoScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("third Scan() error = %v", err)
+	}
+	if hasSource(third, "shop.orders.v1", SourceGoAST) {
+		t.Fatalf("expected changed go.mod to rerun the Go pass, got %#v", third.Topics["shop.orders.v1"])
+	}
+
+	mustWriteFile(t, filepath.Join(repoDir, "src", "main", "resources", "application.properties"), "app.topic=shipments.v2\n")
+	fourth, err := repoScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("fourth Scan() error = %v", err)
+	}
+	if !hasSource(fourth, "shipments.v2", SourceSpring) || hasSource(fourth, "shipments.v1", SourceSpring) {
+		t.Fatalf("expected changed properties to rerun the Spring pass, got %#v", fourth.Topics)
+	}
+}
+
+func TestScanCacheKeyIncludesScanSettings(t *testing.T) {
+	repoDir := t.TempDir()
+	cacheDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "app.yaml"), "topic: orders.created\n")
+
+	for _, tt := range []struct {
+		cfg  Config
+		want int
+	}{
+		{cfg: Config{CacheDir: cacheDir}, want: 0},
+		{cfg: Config{CacheDir: cacheDir}, want: 1},
+		{cfg: Config{CacheDir: cacheDir, MaxFileSize: 1024}, want: 0},
+		{cfg: Config{CacheDir: cacheDir, MaxFileSize: 1024, ScanArchives: true}, want: 0},
+		{cfg: Config{CacheDir: cacheDir, MaxFileSize: 1024, ScanArchives: true}, want: 1},
+	} {
+		repoScanner, err := NewRepoScannerWithConfig(tt.cfg)
+		if err != nil {
+			t.Fatalf("NewRepoScannerWithConfig() error = %v", err)
+		}
+		result, err := repoScanner.Scan(context.Background(), repoDir)
+		if err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if result.CachedFiles != tt.want {
+			t.Fatalf("%+v: CachedFiles = %d, want %d", tt.cfg, result.CachedFiles, tt.want)
+		}
+	}
+}
+
+func TestScanIsDeterministicAcrossWorkers(t *testing.T) {
+	repoDir := t.TempDir()
+	for i := range 40 {
+		mustWriteFile(t, filepath.Join(repoDir, fmt.Sprintf("svc%02d", i), "app.yaml"), fmt.Sprintf(`kafka:
+  topic: orders.v%d
+  topics.regex: "orders\\.v%d\\..*"
+  # kafkaspectre:ignore-next-line
+  dlq_topic: orders.v%d.dlq
+`, i%7, i, i))
+	}
+
+	var results []*Result
+	for _, workers := range []int{1, 8} {
+		repoScanner, err := NewRepoScannerWithConfig(Config{Workers: workers})
+		if err != nil {
+			t.Fatalf("NewRepoScannerWithConfig() error = %v", err)
+		}
+		result, err := repoScanner.Scan(context.Background(), repoDir)
+		if err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		results = append(results, result)
+	}
+
+	if !reflect.DeepEqual(results[0], results[1]) {
+		t.Fatalf("scan output depends on worker count")
+	}
+	if len(results[0].Patterns) != 40 || len(results[0].Suppressed) != 40 {
+		t.Fatalf("patterns = %d, suppressed = %d, want 40 each", len(results[0].Patterns), len(results[0].Suppressed))
+	}
+}
@@ -0,0 +1,140 @@
+package scanner
+
+import (
+	"math"
+	"strings"
+)
+
+// sourceConfidence is the base confidence for each source kind: parsers that
+// understand an API or resource rank above key heuristics, which rank above
+// quoted literals on a line that merely mentions Kafka.
+var sourceConfidence = map[string]float64{
+	SourceTerraform:    0.95,
+	SourceStrimzi:      0.95,
+	SourceManifest:     0.95,
+	SourceAsyncAPI:     0.95,
+	SourceGoAST:        0.9,
+	SourceKafkaConnect: 0.9,
+	SourceKSQL:         0.9,
+	SourceFlinkSQL:     0.9,
+	SourceSpring:       0.9,
+	SourceYAMLJSON:     0.7,
+	SourceEnv:          0.7,
+	SourceProperties:   0.7,
+	SourceHOCON:        0.7,
+	SourceTOML:         0.7,
+	SourceINI:          0.7,
+	SourceKotlin:       0.6,
+	SourceScala:        0.6,
+	SourceGroovy:       0.6,
+	SourceJavaScript:   0.6,
+	SourceCSharp:       0.6,
+	SourceRuby:         0.6,
+	SourceRust:         0.6,
+	SourceElixir:       0.6,
+	SourceRegex:        0.45,
+	SourceJavaClass:    0.4,
+}
+
+// logContextCues mark lines that log, print or raise, where quoted strings
+// are usually messages rather than topic names.
+var logContextCues = []string{
+	"log.", "logger.", "logging.", "slog.", "console.", "print(", "println", "printf(",
+	"errorf(", "errors.new(", "warn(", "debug(", "info(", "raise ", "throw new", "exception(",
+}
+
+// nonTopicSuffixes are literal endings that usually denote files or classes.
+var nonTopicSuffixes = []string{
+	".json", ".yaml", ".yml", ".xml", ".html", ".txt", ".log", ".csv",
+	".java", ".go", ".py", ".js", ".ts", ".properties", ".conf", ".sql",
+}
+
+// PatternConfidence is the confidence of a reference attributed through a
+// subscription pattern.
+const PatternConfidence = 0.6
+
+// referenceConfidence scores a reference between 0 and 1 from its source
+// kind, API context (a known direction), the shape of the topic literal and
+// whether the line it was found on logs or raises (see hasLogContext).
+func referenceConfidence(ref Reference, logContext bool) float64 {
+	score, ok := sourceConfidence[ref.Source]
+	if !ok {
+		score = 0.5
+	}
+
+	if ref.Direction != "" && ref.Direction != DirectionUnknown {
+		score += 0.1
+	}
+
+	topic := strings.ToLower(ref.Topic)
+	switch {
+	case hasAnySuffix(topic, nonTopicSuffixes):
+		score -= 0.3
+	case strings.ContainsAny(topic, ".-_"):
+		score += 0.05
+	case len(topic) < 8 && !strings.ContainsAny(topic, "0123456789"):
+		score -= 0.1
+	}
+
+	if ref.Template != "" {
+		score -= 0.15
+	}
+	for _, binding := range ref.Resolution {
+		if binding.Default {
+			score -= 0.1
+			break
+		}
+	}
+
+	if logContext {
+		score -= 0.3
+	}
+
+	return math.Round(math.Min(1, math.Max(0.05, score))*100) / 100
+}
+
+// hasLogContext reports whether a line contains a logging or error cue. It
+// is computed once per line and shared by the references found on it.
+func hasLogContext(line string) bool {
+	lowerLine := strings.ToLower(line)
+	for _, cue := range logContextCues {
+		if strings.Contains(lowerLine, cue) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnySuffix(value string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(value, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByConfidence drops references and patterns scored below min, and
+// topics left without references.
+func FilterByConfidence(result *Result, min float64) {
+	if min <= 0 {
+		return
+	}
+
+	for topic, topicRef := range result.Topics {
+		kept := topicRef.Occurrences[:0]
+		for _, ref := range topicRef.Occurrences {
+			if ref.Confidence >= min {
+				kept = append(kept, ref)
+			}
+		}
+		topicRef.Occurrences = kept
+		if len(kept) == 0 {
+			delete(result.Topics, topic)
+		}
+	}
+
+	if PatternConfidence < min {
+		result.Patterns = nil
+	}
+}
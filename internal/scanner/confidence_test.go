@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestReferenceConfidence(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  Reference
+		line string
+		want float64
+	}{
+		{name: "go ast with direction", ref: Reference{Topic: "orders.created", Source: SourceGoAST, Direction: DirectionProduce}, want: 1},
+		{name: "config key", ref: Reference{Topic: "orders.created", Source: SourceYAMLJSON, Direction: DirectionUnknown}, want: 0.75},
+		{name: "regex literal", ref: Reference{Topic: "orders.created", Source: SourceRegex}, want: 0.5},
+		{name: "short word", ref: Reference{Topic: "orders", Source: SourceRegex}, want: 0.35},
+		{name: "file name", ref: Reference{Topic: "topics.json", Source: SourceRegex}, want: 0.15},
+		{name: "log line", ref: Reference{Topic: "orders.created", Source: SourceRegex}, line: `log.info("kafka", "orders.created")`, want: 0.2},
+		{name: "template", ref: Reference{Topic: "{env}.orders", Source: SourceGoAST, Direction: DirectionConsume, Template: "{env}.orders"}, want: 0.9},
+		{name: "default resolution", ref: Reference{Topic: "orders.dlq", Source: SourceYAMLJSON, Resolution: []VariableBinding{{Name: "DLQ", Value: "orders.dlq", Default: true}}}, want: 0.65},
+	}
+
+	for _, tt := range tests {
+		if got := referenceConfidence(tt.ref, hasLogContext(tt.line)); got != tt.want {
+			t.Fatalf("%s: referenceConfidence() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFilterByConfidence(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "App.java"), `class App {
+    void run() {
+        kafkaProducer.send(new ProducerRecord<>("orders.created", value));
+        logger.info("kafka publish to {}", "audit.events");
+    }
+}
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if _, ok := result.Topics["audit.events"]; !ok {
+		t.Fatalf("expected low-confidence audit.events before filtering")
+	}
+
+	FilterByConfidence(result, 0.5)
+
+	if _, ok := result.Topics["audit.events"]; ok {
+		t.Fatalf("log-line reference should be filtered at 0.5")
+	}
+	ref, ok := result.Topics["orders.created"]
+	if !ok || ref.Occurrences[0].Confidence < 0.5 {
+		t.Fatalf("orders.created should be kept, got %#v", ref)
+	}
+}
@@ -0,0 +1,263 @@
+package scanner
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SourceKafkaConnect marks topics read or written by Kafka Connect
+// connectors.
+const SourceKafkaConnect = "kafka_connect"
+
+// connectorConfig is one connector's flattened configuration, from a
+// properties file, a REST API JSON/YAML payload or a Strimzi KafkaConnector.
+type connectorConfig struct {
+	class   string
+	entries map[string]propertyEntry
+}
+
+// connectIdentifierPattern matches include-list entries that name a single
+// table or collection rather than a regex.
+var connectIdentifierPattern = regexp.MustCompile(`^[A-Za-z0-9_$-]+(?:\.[A-Za-z0-9_$-]+)*$`)
+
+// scanConnectorConfigs derives the topics each connector in a file reads
+// and writes. Sinks consume topics and topics.regex; sources produce
+// kafka.topic, Debezium's <prefix>.<schema>.<table> topics and the JDBC
+// source's <prefix><table> topics; dead letter queue and schema history
+// topics are produced. The returned lines are the config lines it
+// understood, so heuristic hits there (topic.prefix read as a topic, say)
+// can be dropped.
+func scanConnectorConfigs(path string, content []byte) ([]Reference, []PatternReference, map[int]struct{}) {
+	if !bytes.Contains(content, []byte("connector.class")) && !bytes.Contains(content, []byte("KafkaConnector")) {
+		return nil, nil, nil
+	}
+
+	var (
+		refs     []Reference
+		patterns []PatternReference
+	)
+	handled := make(map[int]struct{})
+	for _, connector := range parseConnectorConfigs(path, content) {
+		connectorRefs, connectorPatterns, lines := connector.topics()
+		refs = append(refs, connectorRefs...)
+		patterns = append(patterns, connectorPatterns...)
+		for _, line := range lines {
+			handled[line] = struct{}{}
+		}
+	}
+	return refs, patterns, handled
+}
+
+func parseConnectorConfigs(path string, content []byte) []connectorConfig {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".properties":
+		entries := parseProperties(content)
+		for _, entry := range entries {
+			if entry.Key == "connector.class" {
+				return []connectorConfig{newConnectorConfig(entries)}
+			}
+		}
+		return nil
+	case ".json":
+		doc, err := parseJSONDocument(content)
+		if err != nil {
+			return nil
+		}
+		return collectConnectorConfigs(doc, nil)
+	default:
+		var connectors []connectorConfig
+		for _, doc := range parseYAMLDocuments(content) {
+			if strings.TrimSpace(doc.get("kind").scalar()) == "KafkaConnector" {
+				spec := doc.get("spec")
+				entries := connectorEntries(spec.get("config"))
+				if class := spec.get("class"); class.scalar() != "" {
+					entries = append(entries, propertyEntry{Key: "connector.class", Value: class.scalar(), Line: class.Line})
+				}
+				connectors = append(connectors, newConnectorConfig(entries))
+				continue
+			}
+			connectors = collectConnectorConfigs(doc, connectors)
+		}
+		return connectors
+	}
+}
+
+// collectConnectorConfigs finds every mapping with a connector.class key,
+// covering bare configs, {"name": ..., "config": {...}} payloads and lists
+// of them.
+func collectConnectorConfigs(node *yamlNode, connectors []connectorConfig) []connectorConfig {
+	if node == nil {
+		return connectors
+	}
+	switch node.Kind {
+	case yamlMapping:
+		if node.get("connector.class").scalar() != "" {
+			return append(connectors, newConnectorConfig(connectorEntries(node)))
+		}
+		for _, key := range node.Keys {
+			connectors = collectConnectorConfigs(node.Fields[key], connectors)
+		}
+	case yamlSequence:
+		for _, item := range node.Items {
+			connectors = collectConnectorConfigs(item, connectors)
+		}
+	}
+	return connectors
+}
+
+func connectorEntries(node *yamlNode) []propertyEntry {
+	if node == nil || node.Kind != yamlMapping {
+		return nil
+	}
+	entries := make([]propertyEntry, 0, len(node.Keys))
+	for _, key := range node.Keys {
+		child := node.Fields[key]
+		entries = append(entries, propertyEntry{Key: key, Value: strings.Join(child.strings(), ","), Line: child.Line})
+	}
+	return entries
+}
+
+func newConnectorConfig(entries []propertyEntry) connectorConfig {
+	connector := connectorConfig{entries: make(map[string]propertyEntry, len(entries))}
+	for _, entry := range entries {
+		entry.Value = strings.TrimSpace(entry.Value)
+		connector.entries[entry.Key] = entry
+	}
+	connector.class = connector.entries["connector.class"].Value
+	return connector
+}
+
+// value returns the first of keys set to a non-empty value.
+func (c connectorConfig) value(keys ...string) (propertyEntry, bool) {
+	for _, key := range keys {
+		if entry, ok := c.entries[key]; ok && entry.Value != "" {
+			return entry, true
+		}
+	}
+	return propertyEntry{}, false
+}
+
+func (c connectorConfig) debezium() bool {
+	return strings.HasPrefix(c.class, "io.debezium.connector.")
+}
+
+// direction is how the connector uses its own topic keys: sinks consume
+// and sources, Debezium included, produce.
+func (c connectorConfig) direction() string {
+	switch {
+	case c.debezium() || strings.Contains(c.class, "Source"):
+		return DirectionProduce
+	case strings.Contains(c.class, "Sink"):
+		return DirectionConsume
+	default:
+		return DirectionUnknown
+	}
+}
+
+func (c connectorConfig) topics() ([]Reference, []PatternReference, []int) {
+	var (
+		refs     []Reference
+		patterns []PatternReference
+		lines    []int
+	)
+	addTopics := func(entry propertyEntry, direction string) {
+		for _, topic := range strings.Split(entry.Value, ",") {
+			if topic = strings.TrimSpace(topic); topic != "" {
+				refs = append(refs, Reference{Topic: topic, Line: entry.Line, Source: SourceKafkaConnect, Direction: direction})
+			}
+		}
+		lines = append(lines, entry.Line)
+	}
+	addPattern := func(pattern string, line int, direction string) {
+		patterns = append(patterns, PatternReference{Pattern: pattern, Line: line, Source: SourceKafkaConnect, Direction: direction})
+		lines = append(lines, line)
+	}
+	// addDerivedTopics turns include-list entries into prefixed topics;
+	// entries that are regexes become patterns with the same prefix.
+	addDerivedTopics := func(prefix string, include propertyEntry) {
+		for _, item := range strings.Split(include.Value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			if name := strings.ReplaceAll(item, `\.`, "."); connectIdentifierPattern.MatchString(name) {
+				refs = append(refs, Reference{Topic: prefix + name, Line: include.Line, Source: SourceKafkaConnect, Direction: DirectionProduce})
+				continue
+			}
+			addPattern(regexp.QuoteMeta(prefix)+item, include.Line, DirectionProduce)
+		}
+		lines = append(lines, include.Line)
+	}
+
+	if entry, ok := c.value("topics"); ok {
+		addTopics(entry, DirectionConsume)
+	}
+	if entry, ok := c.value("topics.regex"); ok {
+		addPattern(entry.Value, entry.Line, DirectionConsume)
+	}
+	for _, key := range []string{"kafka.topic", "topic"} {
+		if entry, ok := c.value(key); ok {
+			addTopics(entry, c.direction())
+		}
+	}
+	for _, key := range []string{"errors.deadletterqueue.topic.name", "schema.history.internal.kafka.topic", "database.history.kafka.topic"} {
+		if entry, ok := c.value(key); ok {
+			addTopics(entry, DirectionProduce)
+		}
+	}
+
+	switch {
+	case c.debezium():
+		prefix, ok := c.value("topic.prefix", "database.server.name", "mongodb.name")
+		if !ok {
+			break
+		}
+		lines = append(lines, prefix.Line)
+		include, ok := c.value("table.include.list", "table.whitelist", "collection.include.list", "collection.whitelist")
+		if !ok {
+			addPattern(regexp.QuoteMeta(prefix.Value)+`\..+`, prefix.Line, DirectionProduce)
+			break
+		}
+		addDerivedTopics(prefix.Value+".", include)
+	case strings.Contains(c.class, "JdbcSourceConnector"):
+		prefix, ok := c.value("topic.prefix")
+		if !ok {
+			break
+		}
+		lines = append(lines, prefix.Line)
+		if _, ok := c.value("query"); ok {
+			refs = append(refs, Reference{Topic: prefix.Value, Line: prefix.Line, Source: SourceKafkaConnect, Direction: DirectionProduce})
+			break
+		}
+		include, ok := c.value("table.include.list", "table.whitelist")
+		if !ok {
+			addPattern(regexp.QuoteMeta(prefix.Value)+".+", prefix.Line, DirectionProduce)
+			break
+		}
+		addDerivedTopics(prefix.Value, include)
+	}
+
+	return refs, patterns, lines
+}
+
+// dropConnectorLines removes heuristic references and patterns on lines a
+// connector config scan already accounted for.
+func dropConnectorLines(result *fileResult, lines map[int]struct{}) {
+	refs := result.References[:0]
+	for _, ref := range result.References {
+		if _, ok := lines[ref.Line]; !ok || ref.Source == SourceKafkaConnect {
+			refs = append(refs, ref)
+		}
+	}
+	result.References = refs
+
+	patterns := result.Patterns[:0]
+	for _, pattern := range result.Patterns {
+		if _, ok := lines[pattern.Line]; !ok || pattern.Source == SourceKafkaConnect {
+			patterns = append(patterns, pattern)
+		}
+	}
+	result.Patterns = patterns
+}
@@ -0,0 +1,125 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanConnectorConfigsDebeziumJSON(t *testing.T) {
+	content := []byte(`{
+  "name": "inventory-cdc",
+  "config": {
+    "connector.class": "io.debezium.connector.postgresql.PostgresConnector",
+    "topic.prefix": "dbserver1",
+    "table.include.list": "inventory.customers,inventory\\.orders,audit\\..*",
+    "schema.history.internal.kafka.topic": "schema-changes.inventory",
+    "errors.deadletterqueue.topic.name": "inventory-cdc.dlq"
+  }
+}
+`)
+
+	refs, patterns, lines := scanConnectorConfigs("connect/inventory.json", content)
+
+	wantRefs := []Reference{
+		{Topic: "inventory-cdc.dlq", Line: 8, Source: SourceKafkaConnect, Direction: DirectionProduce},
+		{Topic: "schema-changes.inventory", Line: 7, Source: SourceKafkaConnect, Direction: DirectionProduce},
+		{Topic: "dbserver1.inventory.customers", Line: 6, Source: SourceKafkaConnect, Direction: DirectionProduce},
+		{Topic: "dbserver1.inventory.orders", Line: 6, Source: SourceKafkaConnect, Direction: DirectionProduce},
+	}
+	if !reflect.DeepEqual(refs, wantRefs) {
+		t.Fatalf("references = %#v, want %#v", refs, wantRefs)
+	}
+	wantPatterns := []PatternReference{{Pattern: `dbserver1\.audit\..*`, Line: 6, Source: SourceKafkaConnect, Direction: DirectionProduce}}
+	if !reflect.DeepEqual(patterns, wantPatterns) {
+		t.Fatalf("patterns = %#v, want %#v", patterns, wantPatterns)
+	}
+	for _, line := range []int{5, 6, 7, 8} {
+		if _, ok := lines[line]; !ok {
+			t.Fatalf("line %d not marked handled: %v", line, lines)
+		}
+	}
+}
+
+func TestScanConnectorConfigsSinkProperties(t *testing.T) {
+	content := []byte(`name=s3-sink
+connector.class=io.confluent.connect.s3.S3SinkConnector
+topics=orders.created, orders.shipped
+errors.deadletterqueue.topic.name=s3-sink.dlq
+`)
+
+	refs, patterns, _ := scanConnectorConfigs("s3-sink.properties", content)
+
+	wantRefs := []Reference{
+		{Topic: "orders.created", Line: 3, Source: SourceKafkaConnect, Direction: DirectionConsume},
+		{Topic: "orders.shipped", Line: 3, Source: SourceKafkaConnect, Direction: DirectionConsume},
+		{Topic: "s3-sink.dlq", Line: 4, Source: SourceKafkaConnect, Direction: DirectionProduce},
+	}
+	if !reflect.DeepEqual(refs, wantRefs) || len(patterns) != 0 {
+		t.Fatalf("references = %#v, patterns = %#v, want %#v", refs, patterns, wantRefs)
+	}
+}
+
+func TestScanConnectorConfigsStrimziAndJDBC(t *testing.T) {
+	content := []byte(`apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaConnector
+metadata:
+  name: elastic-sink
+spec:
+  class: io.confluent.connect.elasticsearch.ElasticsearchSinkConnector
+  config:
+    topics.regex: "clicks\\..*"
+---
+connectors:
+  - name: jdbc-source
+    config:
+      connector.class: io.confluent.connect.jdbc.JdbcSourceConnector
+      topic.prefix: pg-
+      table.whitelist: users,accounts
+  - name: file-source
+    config:
+      connector.class: org.apache.kafka.connect.file.FileStreamSourceConnector
+      topic: file.lines
+`)
+
+	refs, patterns, _ := scanConnectorConfigs("connectors.yaml", content)
+
+	wantRefs := []Reference{
+		{Topic: "pg-users", Line: 15, Source: SourceKafkaConnect, Direction: DirectionProduce},
+		{Topic: "pg-accounts", Line: 15, Source: SourceKafkaConnect, Direction: DirectionProduce},
+		{Topic: "file.lines", Line: 19, Source: SourceKafkaConnect, Direction: DirectionProduce},
+	}
+	if !reflect.DeepEqual(refs, wantRefs) {
+		t.Fatalf("references = %#v, want %#v", refs, wantRefs)
+	}
+	wantPatterns := []PatternReference{{Pattern: `clicks\..*`, Line: 8, Source: SourceKafkaConnect, Direction: DirectionConsume}}
+	if !reflect.DeepEqual(patterns, wantPatterns) {
+		t.Fatalf("patterns = %#v, want %#v", patterns, wantPatterns)
+	}
+}
+
+func TestRepoScannerScanConnectorDropsHeuristicHits(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "connect", "mysql.properties"), `connector.class=io.debezium.connector.mysql.MySqlConnector
+topic.prefix=shop
+table.include.list=shop_db.orders
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if _, ok := result.Topics["shop"]; ok {
+		t.Fatalf("topic.prefix must not be reported as a topic: %#v", result.Topics["shop"])
+	}
+	topicRef := result.Topics["shop.shop_db.orders"]
+	if topicRef == nil || len(topicRef.Occurrences) != 1 {
+		t.Fatalf("expected one shop.shop_db.orders reference, got %#v", result.Topics)
+	}
+	ref := topicRef.Occurrences[0]
+	if ref.File != "connect/mysql.properties" || ref.Line != 3 || ref.Source != SourceKafkaConnect || ref.Direction != DirectionProduce {
+		t.Fatalf("reference = %#v", ref)
+	}
+}
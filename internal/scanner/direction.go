@@ -0,0 +1,87 @@
+package scanner
+
+import "strings"
+
+// Reference directions describe how code uses a topic.
+const (
+	DirectionProduce = "produce"
+	DirectionConsume = "consume"
+	DirectionAdmin   = "admin"
+	DirectionUnknown = "unknown"
+)
+
+type directionKeyword struct {
+	keyword   string
+	direction string
+}
+
+// directionKeywords are lower-case API and naming cues, matched against the
+// text around a reference.
+var directionKeywords = []directionKeyword{
+	{"kafkalistener", DirectionConsume},
+	{"subscribe", DirectionConsume},
+	{"subscription", DirectionConsume},
+	{"consume", DirectionConsume},
+	{"listener", DirectionConsume},
+	{"poll(", DirectionConsume},
+	{"reader", DirectionConsume},
+	{"inbound", DirectionConsume},
+	{"input", DirectionConsume},
+	{"producerrecord", DirectionProduce},
+	{"produce", DirectionProduce},
+	{"kafkatemplate", DirectionProduce},
+	{"send(", DirectionProduce},
+	{"publish", DirectionProduce},
+	{"writer", DirectionProduce},
+	{"deliver_message", DirectionProduce},
+	{"futurerecord", DirectionProduce},
+	{"baserecord", DirectionProduce},
+	{"outbound", DirectionProduce},
+	{"output", DirectionProduce},
+	{"createtopic", DirectionAdmin},
+	{"deletetopic", DirectionAdmin},
+	{"newtopic", DirectionAdmin},
+	{"topicbuilder", DirectionAdmin},
+	{"adminclient", DirectionAdmin},
+	{"kafka_topic", DirectionAdmin},
+	{"kafkatopic", DirectionAdmin},
+}
+
+// maxDirectionContext bounds how much of the line on either side of a
+// reference is searched for cues, so that a long line with many literals
+// is not lowercased and searched once per literal.
+const maxDirectionContext = 256
+
+// classifyDirection picks the cue closest before the reference in prefix,
+// falling back to the first cue in the rest of the line.
+func classifyDirection(prefix, rest string) string {
+	prefix = strings.ToLower(prefix[max(0, len(prefix)-maxDirectionContext):])
+	best := -1
+	bestLen := 0
+	direction := DirectionUnknown
+	for _, kw := range directionKeywords {
+		index := strings.LastIndex(prefix, kw.keyword)
+		if index < 0 {
+			continue
+		}
+		if index > best || (index == best && len(kw.keyword) > bestLen) {
+			best, bestLen, direction = index, len(kw.keyword), kw.direction
+		}
+	}
+	if best >= 0 {
+		return direction
+	}
+
+	rest = strings.ToLower(rest[:min(len(rest), maxDirectionContext)])
+	best = len(rest) + 1
+	for _, kw := range directionKeywords {
+		index := strings.Index(rest, kw.keyword)
+		if index < 0 {
+			continue
+		}
+		if index < best || (index == best && len(kw.keyword) > bestLen) {
+			best, bestLen, direction = index, len(kw.keyword), kw.direction
+		}
+	}
+	return direction
+}
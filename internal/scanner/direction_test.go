@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClassifyDirection(t *testing.T) {
+	tests := []struct {
+		prefix string
+		rest   string
+		want   string
+	}{
+		{prefix: `@KafkaListener(topics = `, want: DirectionConsume},
+		{prefix: `producer.send(new ProducerRecord<>(`, rest: `, key, value));`, want: DirectionProduce},
+		{prefix: `kafkaTemplate.send(`, want: DirectionProduce},
+		{prefix: `consumer.subscribe(List.of(`, want: DirectionConsume},
+		{prefix: `admin.createTopics(List.of(new NewTopic(`, want: DirectionAdmin},
+		{prefix: `// consumer below, producer here: send(`, want: DirectionProduce},
+		{prefix: `String topic = `, rest: `; // used by the consumer`, want: DirectionConsume},
+		{prefix: `String topic = `, want: DirectionUnknown},
+		{prefix: `KAFKA_PRODUCER_TOPIC`, want: DirectionProduce},
+		{prefix: `consumer.subscribe(` + strings.Repeat(" ", maxDirectionContext), want: DirectionUnknown},
+		{prefix: `String topic = `, rest: strings.Repeat(" ", maxDirectionContext) + `// consumer`, want: DirectionUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := classifyDirection(tt.prefix, tt.rest); got != tt.want {
+			t.Fatalf("classifyDirection(%q, %q) = %q, want %q", tt.prefix, tt.rest, got, tt.want)
+		}
+	}
+}
+
+func TestRepoScannerScanDirections(t *testing.T) {
+	repoDir := t.TempDir()
+
+	mustWriteFile(t, filepath.Join(repoDir, "src", "Orders.java"), `class Orders {
+  @KafkaListener(topics = "orders.in")
+  void listen(String value) {
+    kafkaTemplate.send("orders.out", value);
+  }
+}
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "config", "app.yaml"), `kafka:
+  consumer:
+    topics:
+      - payments.in
+  producerTopic: payments.out
+  topic: shared.events
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "main.go"), `package main
+
+import "github.com/twmb/franz-go/pkg/kgo"
+
+func run() {
+	_ = kgo.ConsumeTopics("franz.in")
+	_ = &kgo.Record{Topic: "franz.out"}
+}
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+
+	want := map[string]string{
+		"orders.in":     DirectionConsume,
+		"orders.out":    DirectionProduce,
+		"payments.in":   DirectionConsume,
+		"payments.out":  DirectionProduce,
+		"shared.events": DirectionUnknown,
+		"franz.in":      DirectionConsume,
+		"franz.out":     DirectionProduce,
+	}
+	for topic, direction := range want {
+		ref, ok := result.Topics[topic]
+		if !ok {
+			t.Fatalf("missing topic %q", topic)
+		}
+		if got := ref.Occurrences[len(ref.Occurrences)-1].Direction; got != direction {
+			t.Fatalf("%s direction = %q, want %q", topic, got, direction)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// gitTreeEntry is one file in a revision's tree.
+type gitTreeEntry struct {
+	mode   string
+	object string
+	size   int64
+}
+
+// regular reports whether the entry is a plain file, not a symlink or a
+// submodule.
+func (e gitTreeEntry) regular() bool {
+	return e.mode == "100644" || e.mode == "100755"
+}
+
+// ScanRevision scans the tree of a git revision (branch, tag or commit)
+// straight from the object database without checking it out. When
+// repoPath is a subdirectory of the repository only that subtree is
+// scanned.
+func (s *RepoScanner) ScanRevision(ctx context.Context, repoPath, revision string) (*Result, error) {
+	absRepoPath, err := resolveRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := resolveCommit(ctx, absRepoPath, revision)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := listTree(ctx, absRepoPath, commit)
+	if err != nil {
+		return nil, err
+	}
+	blobs, err := openBlobReader(ctx, absRepoPath)
+	if err != nil {
+		return nil, err
+	}
+	defer blobs.close()
+
+	read := func(filePath string) ([]byte, error) {
+		relPath, err := filepath.Rel(absRepoPath, filePath)
+		if err != nil {
+			return nil, err
+		}
+		entry, ok := entries[filepath.ToSlash(relPath)]
+		if !ok || !entry.regular() {
+			return nil, fs.ErrNotExist
+		}
+		return blobs.read(entry.object)
+	}
+
+	result := newResult(absRepoPath)
+	result.Revision = commit
+	files, err := s.walkTree(ctx, absRepoPath, entries, read, result)
+	if err != nil {
+		return nil, err
+	}
+	return s.scanWalked(ctx, result, files, read)
+}
+
+// walkTree applies the same skip rules as walk to a revision's file list.
+// Ignore files are read from the revision itself.
+func (s *RepoScanner) walkTree(ctx context.Context, absRepoPath string, entries map[string]gitTreeEntry, read readFunc, result *Result) ([]walkedFile, error) {
+	paths := make([]string, 0, len(entries))
+	for relPath := range entries {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+
+	// Rules from an ignore file cover its directory and below, so deeper
+	// files are loaded later and take precedence.
+	var ignoreFiles []string
+	for _, relPath := range paths {
+		if slices.Contains(ignoreFileNames, path.Base(relPath)) {
+			ignoreFiles = append(ignoreFiles, relPath)
+		}
+	}
+	sort.SliceStable(ignoreFiles, func(i, j int) bool {
+		return strings.Count(ignoreFiles[i], "/") < strings.Count(ignoreFiles[j], "/")
+	})
+	var rules ignoreRules
+	for _, relPath := range ignoreFiles {
+		content, err := read(filepath.Join(absRepoPath, filepath.FromSlash(relPath)))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", relPath, err)
+		}
+		relDir := path.Dir(relPath)
+		if relDir == "." {
+			relDir = ""
+		}
+		rules = appendIgnoreRules(rules, content, relDir)
+	}
+
+	var files []walkedFile
+	dirReasons := make(map[string]string)
+	for _, relPath := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if s.treeDirSkipped(relPath, rules, dirReasons, result) {
+			continue
+		}
+		entry := entries[relPath]
+		if !entry.regular() {
+			continue
+		}
+
+		mode := detectScanMode(relPath)
+		if reason := s.fileSkipReason(relPath, mode, rules); reason != "" {
+			result.SkippedFiles[reason]++
+			continue
+		}
+		if entry.size > s.sizeLimit(mode) {
+			result.SkippedFiles[SkipReasonTooLarge]++
+			continue
+		}
+
+		files = append(files, walkedFile{
+			path:    filepath.Join(absRepoPath, filepath.FromSlash(relPath)),
+			relPath: relPath,
+			mode:    mode,
+		})
+	}
+	return files, nil
+}
+
+// treeDirSkipped reports whether any directory above relPath is skipped,
+// counting each skipped directory once.
+func (s *RepoScanner) treeDirSkipped(relPath string, rules ignoreRules, dirReasons map[string]string, result *Result) bool {
+	for idx := range len(relPath) {
+		if relPath[idx] != '/' {
+			continue
+		}
+		dir := relPath[:idx]
+		reason, seen := dirReasons[dir]
+		if !seen {
+			reason = s.dirSkipReason(path.Base(dir), dir, rules)
+			dirReasons[dir] = reason
+			if reason != "" {
+				result.SkippedDirs[reason]++
+			}
+		}
+		if reason != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangedFiles lists files changed since the merge base of since and
+// revision, or of since and the working tree when revision is empty
+// (untracked files included). Paths are relative to repoPath and deleted
+// files are left out.
+func ChangedFiles(ctx context.Context, repoPath, since, revision string) ([]string, error) {
+	absRepoPath, err := resolveRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(since) == "" {
+		return nil, errors.New("changed-since revision is required")
+	}
+	// Revisions are resolved to commit IDs first so that a value such as
+	// "--output=x" can never reach git diff as an option.
+	sinceCommit, err := resolveCommit(ctx, absRepoPath, since)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"diff", "--name-only", "-z", "--relative", "--no-renames", "--diff-filter=d"}
+	if revision != "" {
+		commit, err := resolveCommit(ctx, absRepoPath, revision)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, sinceCommit+"..."+commit)
+	} else {
+		args = append(args, "--merge-base", sinceCommit)
+	}
+	out, err := runGit(ctx, absRepoPath, args...)
+	if err != nil {
+		return nil, err
+	}
+	files := splitNUL(out)
+
+	if revision == "" {
+		untracked, err := runGit(ctx, absRepoPath, "ls-files", "--others", "--exclude-standard", "-z")
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, splitNUL(untracked)...)
+	}
+
+	sort.Strings(files)
+	return slices.Compact(files), nil
+}
+
+func resolveCommit(ctx context.Context, dir, revision string) (string, error) {
+	revision = strings.TrimSpace(revision)
+	if revision == "" {
+		return "", errors.New("git revision is required")
+	}
+	out, err := runGit(ctx, dir, "rev-parse", "--verify", "--end-of-options", revision+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("resolve revision %q: %w", revision, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// listTree returns the files below dir at commit, keyed by path relative
+// to dir.
+func listTree(ctx context.Context, dir, commit string) (map[string]gitTreeEntry, error) {
+	out, err := runGit(ctx, dir, "ls-tree", "-r", "-z", "--long", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]gitTreeEntry)
+	for _, record := range splitNUL(out) {
+		// <mode> SP <type> SP <object> SP+ <size> TAB <path>
+		meta, relPath, ok := strings.Cut(record, "\t")
+		if !ok {
+			return nil, fmt.Errorf("git ls-tree: unexpected record %q", record)
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("git ls-tree: unexpected record %q", record)
+		}
+		size, _ := strconv.ParseInt(fields[3], 10, 64)
+		entries[relPath] = gitTreeEntry{mode: fields[0], object: fields[2], size: size}
+	}
+	return entries, nil
+}
+
+// blobReader serves blob contents from a long-running git cat-file
+// --batch process. Reads are serialised; parsing still runs in parallel.
+type blobReader struct {
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+func openBlobReader(ctx context.Context, dir string) (*blobReader, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "cat-file", "--batch")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+	return &blobReader{cmd: cmd, stdin: stdin, stdout: bufio.NewReader(stdout)}, nil
+}
+
+func (r *blobReader) read(object string) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := fmt.Fprintln(r.stdin, object); err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+	header, err := r.stdout.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+	// <object> SP <type> SP <size> LF, or <object> SP missing LF
+	fields := strings.Fields(header)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("git cat-file: object %s: %s", object, strings.TrimSpace(header))
+	}
+	size, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file: object %s: bad size %q", object, fields[2])
+	}
+
+	content := make([]byte, size+1)
+	if _, err := io.ReadFull(r.stdout, content); err != nil {
+		return nil, fmt.Errorf("git cat-file: object %s: %w", object, err)
+	}
+	return content[:size], nil
+}
+
+func (r *blobReader) close() {
+	r.stdin.Close()
+	_ = r.cmd.Wait()
+}
+
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+func splitNUL(out []byte) []string {
+	var items []string
+	for _, item := range strings.Split(string(out), "\x00") {
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
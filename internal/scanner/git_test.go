@@ -0,0 +1,120 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanRevisionReadsObjectDatabase(t *testing.T) {
+	repoDir := initGitRepo(t)
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "app.yaml"), "topic: orders.created\n")
+	mustWriteFile(t, filepath.Join(repoDir, "svc", ".kafkaspectreignore"), "fixtures/\n")
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "fixtures", "app.yaml"), "topic: orders.fixture\n")
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "go.mod"), "module example.com/svc\n")
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "producer.go"), `package svc
+
+const ordersTopic = "orders.go"
+
+func send(p Producer) { p.Produce(&kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &ordersTopic}}) }
+`)
+	gitCommit(t, repoDir, "initial")
+
+	// Working-tree edits after the commit must not leak into the revision scan.
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "app.yaml"), "topic: orders.uncommitted\n")
+
+	result, err := NewRepoScanner().ScanRevision(context.Background(), filepath.Join(repoDir, "svc"), "HEAD")
+	if err != nil {
+		t.Fatalf("ScanRevision() error = %v", err)
+	}
+	if len(result.Revision) != 40 {
+		t.Fatalf("Revision = %q, want a commit id", result.Revision)
+	}
+	for _, topic := range []string{"orders.created", "orders.go"} {
+		if _, ok := result.Topics[topic]; !ok {
+			t.Fatalf("expected topic %q, got %#v", topic, result.Topics)
+		}
+	}
+	for _, topic := range []string{"orders.uncommitted", "orders.fixture"} {
+		if _, ok := result.Topics[topic]; ok {
+			t.Fatalf("unexpected topic %q", topic)
+		}
+	}
+	if ref := result.Topics["orders.created"].Occurrences[0]; ref.File != "app.yaml" {
+		t.Fatalf("file = %q, want path relative to the scanned subtree", ref.File)
+	}
+	if result.SkippedDirs[SkipReasonIgnoreFile] != 1 {
+		t.Fatalf("SkippedDirs = %#v", result.SkippedDirs)
+	}
+
+	if _, err := NewRepoScanner().ScanRevision(context.Background(), repoDir, "no-such-ref"); err == nil {
+		t.Fatalf("expected error for unknown revision")
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	repoDir := initGitRepo(t)
+	mustWriteFile(t, filepath.Join(repoDir, "a.yaml"), "topic: orders.a\n")
+	mustWriteFile(t, filepath.Join(repoDir, "b.yaml"), "topic: orders.b\n")
+	gitCommit(t, repoDir, "base")
+	runGitCommand(t, repoDir, "branch", "base")
+
+	mustWriteFile(t, filepath.Join(repoDir, "b.yaml"), "topic: orders.b2\n")
+	mustWriteFile(t, filepath.Join(repoDir, "c.yaml"), "topic: orders.c\n")
+	if err := os.Remove(filepath.Join(repoDir, "a.yaml")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	gitCommit(t, repoDir, "change")
+	mustWriteFile(t, filepath.Join(repoDir, "d.yaml"), "topic: orders.d\n")
+
+	got, err := ChangedFiles(context.Background(), repoDir, "base", "HEAD")
+	if err != nil {
+		t.Fatalf("ChangedFiles() error = %v", err)
+	}
+	if want := []string{"b.yaml", "c.yaml"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ChangedFiles(HEAD) = %v, want %v", got, want)
+	}
+
+	got, err = ChangedFiles(context.Background(), repoDir, "base", "")
+	if err != nil {
+		t.Fatalf("ChangedFiles() error = %v", err)
+	}
+	if want := []string{"b.yaml", "c.yaml", "d.yaml"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ChangedFiles(worktree) = %v, want %v", got, want)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "injected")
+	if _, err := ChangedFiles(context.Background(), repoDir, "--output="+outPath, ""); err == nil {
+		t.Fatalf("ChangedFiles() with an option-like revision: expected an error")
+	}
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Fatalf("option-like revision reached git diff: stat %s: %v", outPath, err)
+	}
+}
+
+func initGitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repoDir := t.TempDir()
+	runGitCommand(t, repoDir, "init", "-q")
+	return repoDir
+}
+
+func gitCommit(t *testing.T, repoDir, message string) {
+	t.Helper()
+	runGitCommand(t, repoDir, "add", "-A")
+	runGitCommand(t, repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", message)
+}
+
+func runGitCommand(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
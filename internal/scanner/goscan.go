@@ -0,0 +1,564 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SourceGoAST marks Go references resolved from the syntax tree.
+const SourceGoAST = "go_ast"
+
+// goKafkaImports are import path prefixes of supported Go Kafka clients.
+// AST extraction only runs on files that import one of them.
+var goKafkaImports = []string{
+	"github.com/twmb/franz-go",
+	"github.com/IBM/sarama",
+	"github.com/Shopify/sarama",
+	"github.com/segmentio/kafka-go",
+	"github.com/confluentinc/confluent-kafka-go",
+	"gopkg.in/confluentinc/confluent-kafka-go",
+}
+
+// goTopicCall describes a client API whose string arguments are topic
+// names. arg is the argument index to inspect, or -1 for all.
+type goTopicCall struct {
+	arg       int
+	direction string
+}
+
+var goTopicCalls = map[string]goTopicCall{
+	"ConsumeTopics":       {-1, DirectionConsume}, // franz-go kgo option
+	"DefaultProduceTopic": {-1, DirectionProduce}, // franz-go kgo option
+	"SubscribeTopics":     {-1, DirectionConsume}, // confluent-kafka-go
+	"Subscribe":           {-1, DirectionConsume}, // confluent-kafka-go
+	"ConsumePartition":    {-1, DirectionConsume}, // sarama
+	"Consume":             {-1, DirectionConsume}, // sarama ConsumerGroup
+	"CreateTopic":         {-1, DirectionAdmin},   // sarama ClusterAdmin, franz-go kadm
+	"CreateTopics":        {-1, DirectionAdmin},   // franz-go kadm
+	"DeleteTopic":         {-1, DirectionAdmin},   // sarama ClusterAdmin
+	"DeleteTopics":        {-1, DirectionAdmin},   // franz-go kadm
+	"DialLeader":          {3, DirectionUnknown},  // segmentio/kafka-go (ctx, network, address, topic, partition)
+}
+
+// goTopicFields are struct literal fields holding topic names, e.g.
+// sarama.ProducerMessage{Topic: ...} or kafka.ReaderConfig{GroupTopics: ...}.
+var goTopicFields = map[string]struct{}{
+	"Topic":       {},
+	"Topics":      {},
+	"GroupTopics": {},
+}
+
+// goTopicStructDirections maps struct literal types to the direction of
+// their topic fields.
+var goTopicStructDirections = map[string]string{
+	"ProducerMessage":    DirectionProduce, // sarama
+	"Record":             DirectionProduce, // franz-go kgo
+	"Message":            DirectionProduce, // segmentio/kafka-go, confluent-kafka-go
+	"Writer":             DirectionProduce, // segmentio/kafka-go
+	"WriterConfig":       DirectionProduce, // segmentio/kafka-go
+	"ReaderConfig":       DirectionConsume, // segmentio/kafka-go
+	"TopicConfig":        DirectionAdmin,   // segmentio/kafka-go
+	"TopicSpecification": DirectionAdmin,   // confluent-kafka-go
+}
+
+// goSourceFile is a Go file collected during the walk for package-level
+// analysis after all files are known.
+type goSourceFile struct {
+	path    string
+	relPath string
+	content []byte
+}
+
+type goPackageKey struct {
+	dir  string
+	name string
+}
+
+type goPackage struct {
+	importPath string
+	sources    []goSourceFile
+	usesKafka  bool
+	files      []*ast.File
+	relPaths   []string
+	checked    *types.Package
+	info       *types.Info
+	checking   bool
+}
+
+// goLoader parses and type-checks repository packages on demand. Packages
+// outside the repository are replaced by empty stubs, so only constants
+// defined in the repository resolve; type errors are expected and ignored.
+type goLoader struct {
+	fset         *token.FileSet
+	repoPath     string
+	packages     map[goPackageKey]*goPackage
+	byImportPath map[string]*goPackage
+	stubs        map[string]*types.Package
+	modules      map[string]string
+	read         readFunc
+}
+
+// scanGoFiles resolves topic names passed to Go Kafka client APIs, following
+// string constants, simple concatenations and single-assignment variables
+// across files and packages of the repository. Results are keyed by the
+// file's repository-relative path. go.mod files are loaded with read.
+//
+// Files are first parsed up to their imports. Only packages with a file
+// importing a Kafka client are type-checked, together with the repository
+// packages they import; every other package keeps the regex results.
+func scanGoFiles(repoPath string, files []goSourceFile, read readFunc) map[string][]Reference {
+	loader := &goLoader{
+		fset:         token.NewFileSet(),
+		repoPath:     repoPath,
+		packages:     make(map[goPackageKey]*goPackage),
+		byImportPath: make(map[string]*goPackage),
+		stubs:        make(map[string]*types.Package),
+		modules:      make(map[string]string),
+		read:         read,
+	}
+
+	imports := token.NewFileSet()
+	for _, file := range files {
+		parsed, err := parser.ParseFile(imports, file.path, file.content, parser.ImportsOnly)
+		if err != nil {
+			continue
+		}
+		dir := filepath.Dir(file.path)
+		key := goPackageKey{dir: dir, name: parsed.Name.Name}
+		pkg, ok := loader.packages[key]
+		if !ok {
+			pkg = &goPackage{importPath: loader.importPath(dir)}
+			if strings.HasSuffix(key.name, "_test") {
+				pkg.importPath += "_test"
+			} else if _, taken := loader.byImportPath[pkg.importPath]; !taken {
+				loader.byImportPath[pkg.importPath] = pkg
+			}
+			loader.packages[key] = pkg
+		}
+		pkg.sources = append(pkg.sources, file)
+		pkg.usesKafka = pkg.usesKafka || importsGoKafkaClient(parsed)
+	}
+
+	keys := make([]goPackageKey, 0, len(loader.packages))
+	for key, pkg := range loader.packages {
+		if pkg.usesKafka {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].dir != keys[j].dir {
+			return keys[i].dir < keys[j].dir
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	out := make(map[string][]Reference)
+	for _, key := range keys {
+		pkg := loader.packages[key]
+		loader.check(pkg)
+		variables := goVariableValues(pkg)
+		for i, file := range pkg.files {
+			if !importsGoKafkaClient(file) {
+				continue
+			}
+			out[pkg.relPaths[i]] = append(out[pkg.relPaths[i]], loader.extract(pkg, file, variables)...)
+		}
+	}
+
+	return out
+}
+
+// Import implements types.Importer.
+func (l *goLoader) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := l.byImportPath[importPath]; ok && !pkg.checking {
+		l.check(pkg)
+		if pkg.checked != nil {
+			return pkg.checked, nil
+		}
+	}
+
+	if stub, ok := l.stubs[importPath]; ok {
+		return stub, nil
+	}
+	stub := types.NewPackage(importPath, goStubPackageName(importPath))
+	stub.MarkComplete()
+	l.stubs[importPath] = stub
+	return stub, nil
+}
+
+func (l *goLoader) check(pkg *goPackage) {
+	if pkg.info != nil || pkg.checking {
+		return
+	}
+	pkg.checking = true
+	defer func() { pkg.checking = false }()
+
+	for _, source := range pkg.sources {
+		parsed, err := parser.ParseFile(l.fset, source.path, source.content, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		pkg.files = append(pkg.files, parsed)
+		pkg.relPaths = append(pkg.relPaths, source.relPath)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{
+		Importer:    l,
+		Error:       func(error) {},
+		FakeImportC: true,
+	}
+	checked, _ := conf.Check(pkg.importPath, l.fset, pkg.files, info)
+	pkg.checked = checked
+	pkg.info = info
+}
+
+func (l *goLoader) extract(pkg *goPackage, file *ast.File, variables map[types.Object][]string) []Reference {
+	refs := make([]Reference, 0)
+	emit := func(expr ast.Expr, direction string) {
+		for _, value := range goStringValues(expr, pkg.info, variables) {
+			template := ""
+			if isTemplateTopicCandidate(value.topic) {
+				template = value.topic
+			} else if !topicLiteralPattern.MatchString(value.topic) {
+				continue
+			}
+			position := l.fset.Position(value.pos)
+			ref := Reference{
+				Topic:     value.topic,
+				Line:      position.Line,
+				Column:    position.Column,
+				Source:    SourceGoAST,
+				Direction: direction,
+				Template:  template,
+			}
+			if value.literal && template == "" {
+				// The position is the opening quote.
+				ref.LiteralColumn = position.Column + 1
+			}
+			refs = append(refs, ref)
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			call, ok := goTopicCalls[goCalleeName(node.Fun)]
+			if !ok {
+				return true
+			}
+			for i, arg := range node.Args {
+				if call.arg < 0 || i == call.arg {
+					emit(arg, call.direction)
+				}
+			}
+		case *ast.CompositeLit:
+			direction, ok := goTopicStructDirections[goCalleeName(node.Type)]
+			if !ok {
+				direction = DirectionUnknown
+			}
+			for _, elt := range node.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if _, ok := goTopicFields[key.Name]; ok {
+					emit(kv.Value, direction)
+				}
+			}
+		}
+		return true
+	})
+
+	return refs
+}
+
+type goStringValue struct {
+	topic string
+	pos   token.Pos
+	// literal marks a string literal spelling topic verbatim at pos.
+	literal bool
+}
+
+// goStringValues resolves an expression to the topic strings it denotes:
+// constant expressions, string slices and map keys, &x, variables assigned
+// exactly once from constants, and fmt.Sprintf calls or concatenations with
+// non-constant parts, which yield {name} templates.
+func goStringValues(expr ast.Expr, info *types.Info, variables map[types.Object][]string) []goStringValue {
+	if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+		if tv.Value.Kind() == constant.String {
+			topic := constant.StringVal(tv.Value)
+			lit, ok := expr.(*ast.BasicLit)
+			literal := ok && len(lit.Value) >= 2 && lit.Value[1:len(lit.Value)-1] == topic
+			return []goStringValue{{topic: topic, pos: expr.Pos(), literal: literal}}
+		}
+		return nil
+	}
+
+	switch node := expr.(type) {
+	case *ast.ParenExpr:
+		return goStringValues(node.X, info, variables)
+	case *ast.UnaryExpr:
+		if node.Op == token.AND {
+			return goStringValues(node.X, info, variables)
+		}
+	case *ast.CompositeLit:
+		out := make([]goStringValue, 0, len(node.Elts))
+		for _, elt := range node.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Key
+			}
+			out = append(out, goStringValues(elt, info, variables)...)
+		}
+		return out
+	case *ast.CallExpr:
+		return goSprintfValues(node, info, variables)
+	case *ast.BinaryExpr:
+		if node.Op == token.ADD {
+			return goConcatValues(node, info, variables)
+		}
+	case *ast.Ident:
+		obj := info.Uses[node]
+		if obj == nil {
+			return nil
+		}
+		values := variables[obj]
+		out := make([]goStringValue, 0, len(values))
+		for _, value := range values {
+			out = append(out, goStringValue{topic: value, pos: node.Pos()})
+		}
+		return out
+	}
+
+	return nil
+}
+
+func goSprintfValues(call *ast.CallExpr, info *types.Info, variables map[types.Object][]string) []goStringValue {
+	if goCalleeName(call.Fun) != "Sprintf" || len(call.Args) == 0 {
+		return nil
+	}
+	tv, ok := info.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil
+	}
+
+	args := make([]string, 0, len(call.Args)-1)
+	for _, arg := range call.Args[1:] {
+		part := goTemplatePart(arg, info, variables)
+		if part == "" {
+			return nil
+		}
+		args = append(args, part)
+	}
+	template, ok := printfTemplate(constant.StringVal(tv.Value), args)
+	if !ok {
+		return nil
+	}
+	return []goStringValue{{topic: template, pos: call.Pos()}}
+}
+
+// goConcatValues handles a + b where at least one side is not constant; a
+// fully constant concatenation is folded by the type checker already.
+func goConcatValues(expr *ast.BinaryExpr, info *types.Info, variables map[types.Object][]string) []goStringValue {
+	left := goTemplatePart(expr.X, info, variables)
+	right := goTemplatePart(expr.Y, info, variables)
+	if left == "" || right == "" {
+		return nil
+	}
+	return []goStringValue{{topic: left + right, pos: expr.Pos()}}
+}
+
+// goTemplatePart renders one operand of a template: its string value when it
+// resolves to exactly one, otherwise a placeholder named after it.
+func goTemplatePart(expr ast.Expr, info *types.Info, variables map[types.Object][]string) string {
+	if values := goStringValues(expr, info, variables); len(values) == 1 {
+		return values[0].topic
+	}
+	if name := goPlaceholderName(expr, info); name != "" {
+		return placeholder(name)
+	}
+	return ""
+}
+
+// goPlaceholderName names a non-constant operand: env, cfg.Env, the key of
+// os.Getenv("ENV") or m["env"], or the called function.
+func goPlaceholderName(expr ast.Expr, info *types.Info) string {
+	switch node := expr.(type) {
+	case *ast.Ident:
+		return node.Name
+	case *ast.SelectorExpr:
+		return node.Sel.Name
+	case *ast.ParenExpr:
+		return goPlaceholderName(node.X, info)
+	case *ast.StarExpr:
+		return goPlaceholderName(node.X, info)
+	case *ast.IndexExpr:
+		if tv, ok := info.Types[node.Index]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return templatePlaceholderName(constant.StringVal(tv.Value))
+		}
+		return goPlaceholderName(node.X, info)
+	case *ast.CallExpr:
+		for _, arg := range node.Args {
+			if tv, ok := info.Types[arg]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+				return templatePlaceholderName(constant.StringVal(tv.Value))
+			}
+		}
+		return goCalleeName(node.Fun)
+	}
+	return ""
+}
+
+// goVariableValues records variables initialised from constant strings (or
+// slices of them) that are never reassigned.
+func goVariableValues(pkg *goPackage) map[types.Object][]string {
+	values := make(map[types.Object][]string)
+	reassigned := make(map[types.Object]struct{})
+
+	record := func(ident *ast.Ident, expr ast.Expr) {
+		obj := pkg.info.Defs[ident]
+		if obj == nil {
+			return
+		}
+		if _, ok := obj.(*types.Var); !ok {
+			return
+		}
+		resolved := goStringValues(expr, pkg.info, nil)
+		if len(resolved) == 0 {
+			return
+		}
+		strs := make([]string, 0, len(resolved))
+		for _, value := range resolved {
+			strs = append(strs, value.topic)
+		}
+		values[obj] = strs
+	}
+
+	for _, file := range pkg.files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range node.Lhs {
+					ident, ok := lhs.(*ast.Ident)
+					if !ok {
+						continue
+					}
+					if node.Tok == token.DEFINE && len(node.Lhs) == len(node.Rhs) {
+						record(ident, node.Rhs[i])
+					}
+					if obj := pkg.info.Uses[ident]; obj != nil {
+						reassigned[obj] = struct{}{}
+					}
+				}
+			case *ast.ValueSpec:
+				if len(node.Names) != len(node.Values) {
+					return true
+				}
+				for i, name := range node.Names {
+					record(name, node.Values[i])
+				}
+			}
+			return true
+		})
+	}
+
+	for obj := range reassigned {
+		delete(values, obj)
+	}
+	return values
+}
+
+func goCalleeName(fun ast.Expr) string {
+	switch node := fun.(type) {
+	case *ast.SelectorExpr:
+		return node.Sel.Name
+	case *ast.Ident:
+		return node.Name
+	case *ast.IndexExpr:
+		return goCalleeName(node.X)
+	default:
+		return ""
+	}
+}
+
+func importsGoKafkaClient(file *ast.File) bool {
+	for _, spec := range file.Imports {
+		importPath := strings.Trim(spec.Path.Value, "\"`")
+		for _, prefix := range goKafkaImports {
+			if strings.HasPrefix(importPath, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// importPath derives a directory's import path from the nearest go.mod
+// inside the repository, falling back to the repository-relative path.
+func (l *goLoader) importPath(dir string) string {
+	for current := dir; ; current = filepath.Dir(current) {
+		if module := l.modulePath(current); module != "" {
+			rel, err := filepath.Rel(current, dir)
+			if err != nil || rel == "." {
+				return module
+			}
+			return path.Join(module, filepath.ToSlash(rel))
+		}
+		if current == l.repoPath || filepath.Dir(current) == current {
+			break
+		}
+	}
+
+	rel, err := filepath.Rel(l.repoPath, dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (l *goLoader) modulePath(dir string) string {
+	if module, ok := l.modules[dir]; ok {
+		return module
+	}
+
+	module := ""
+	if content, err := l.read(filepath.Join(dir, "go.mod")); err == nil {
+		lines := bufio.NewScanner(bytes.NewReader(content))
+		for lines.Scan() {
+			fields := strings.Fields(lines.Text())
+			if len(fields) >= 2 && fields[0] == "module" {
+				module = strings.Trim(fields[1], "\"`")
+				break
+			}
+		}
+	}
+	l.modules[dir] = module
+	return module
+}
+
+// goStubPackageName guesses the package name of an external import so that
+// qualified identifiers resolve to the stub instead of being undeclared.
+func goStubPackageName(importPath string) string {
+	name := path.Base(importPath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(importPath))
+	}
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "go-"), "-go")
+	return strings.NewReplacer("-", "_", ".", "_").Replace(name)
+}
@@ -0,0 +1,105 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestRepoScannerScanGoAST(t *testing.T) {
+	repoDir := t.TempDir()
+
+	mustWriteFile(t, filepath.Join(repoDir, "go.mod"), "module example.com/shop\n\ngo 1.22\n")
+	mustWriteFile(t, filepath.Join(repoDir, "internal", "names", "names.go"), `package names
+
+const (
+	Prefix = "shop."
+	Orders = Prefix + "orders.v1"
+)
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "cmd", "worker", "consts.go"), `package main
+
+const PaymentsTopic = "payments.v1"
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "cmd", "worker", "main.go"), `package main
+
+import (
+	"context"
+
+	"example.com/shop/internal/names"
+	"github.com/IBM/sarama"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func run(ctx context.Context) {
+	client, _ := kgo.NewClient(
+		kgo.ConsumeTopics(PaymentsTopic, names.Orders),
+	)
+	_ = client
+
+	refunds := "refunds" + ".v2"
+	_ = &sarama.ProducerMessage{Topic: refunds, Value: sarama.StringEncoder("not.a.topic")}
+
+	_ = kafkago.ReaderConfig{GroupTopics: []string{"inventory.v1", names.Prefix + "audit"}}
+	_, _ = kafkago.DialLeader(ctx, "tcp", "localhost:9092", "leader.topic", 0)
+
+	mutable := "mutable.first"
+	mutable = "mutable.second"
+	_ = &sarama.ProducerMessage{Topic: mutable}
+}
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "cmd", "other", "main.go"), `package main
+
+type Message struct{ Topic string }
+
+var _ = Message{Topic: "unrelated.struct"}
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+
+	// The third value is the literal column: only literals spelling the
+	// topic verbatim get one, identifiers and concatenations do not.
+	want := map[string][3]int{
+		"payments.v1":    {14, 21, 0},
+		"shop.orders.v1": {14, 36, 0},
+		"refunds.v2":     {19, 37, 0},
+		"inventory.v1":   {21, 49, 50},
+		"shop.audit":     {21, 65, 0},
+		"leader.topic":   {22, 58, 59},
+	}
+	for topic, pos := range want {
+		ref, ok := findSourceReference(result, topic, SourceGoAST)
+		if !ok {
+			t.Fatalf("expected %s reference for %q, got %+v", SourceGoAST, topic, result.Topics[topic])
+		}
+		if ref.LiteralColumn != pos[2] {
+			t.Fatalf("%s literal column = %d, want %d", topic, ref.LiteralColumn, pos[2])
+		}
+		if ref.File != "cmd/worker/main.go" || ref.Line != pos[0] || ref.Column != pos[1] {
+			t.Fatalf("%s position = %s:%d:%d, want cmd/worker/main.go:%d:%d", topic, ref.File, ref.Line, ref.Column, pos[0], pos[1])
+		}
+	}
+
+	for _, topic := range []string{"not.a.topic", "localhost:9092", "mutable.first", "mutable.second", "unrelated.struct"} {
+		if hasSource(result, topic, SourceGoAST) {
+			t.Fatalf("unexpected %s reference for %q", SourceGoAST, topic)
+		}
+	}
+}
+
+func findSourceReference(result *Result, topic, source string) (Reference, bool) {
+	ref, ok := result.Topics[topic]
+	if !ok {
+		return Reference{}, false
+	}
+	for _, occ := range ref.Occurrences {
+		if occ.Source == source {
+			return occ, true
+		}
+	}
+	return Reference{}, false
+}
@@ -0,0 +1,303 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	SourceKotlin     = "kotlin"
+	SourceScala      = "scala"
+	SourceGroovy     = "groovy"
+	SourceJavaScript = "javascript"
+	SourceCSharp     = "csharp"
+	SourceRuby       = "ruby"
+	SourceRust       = "rust"
+	SourceElixir     = "elixir"
+)
+
+// sourceLanguage configures literal-aware scanning for one language.
+type sourceLanguage struct {
+	source string
+	syntax literalSyntax
+	// hints are lower-case substrings; a literal is only considered when its
+	// line mentions one of them.
+	hints []string
+	// callPattern matches client API calls whose first argument (or first
+	// list argument) is a topic, e.g. consumer.Subscribe(...).
+	callPattern *regexp.Regexp
+	// callLeadingArgs allows identifier arguments before the topic, as in
+	// :brod.produce_sync(client, "orders", ...).
+	callLeadingArgs bool
+	// symbolPattern extracts topics spelled as symbols, e.g. karafka's
+	// `topic :orders`; symbolDirection is their direction.
+	symbolPattern   *regexp.Regexp
+	symbolDirection string
+}
+
+const (
+	literalArgPattern  = "(?:\\w{0,3}\"[^\"]*\"|'[^']*'|`[^`]*`|:\\w+)"
+	listOpenerPattern  = `(?:&?\[|vec!\[|new\s*\w*\s*\[\]\s*\{|(?i:listOf|setOf|seq|list|set|arrays\.aslist|java\.util\.list\.of)\()`
+	leadingArgsPattern = `(?:[\w:.&]+\s*,\s*)*`
+)
+
+var (
+	topicLiteralPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{3,249}$`)
+	// topicKeyPattern finds topic keys such as `topic: ...`, `Topic = ...`
+	// or `:topic => ...` anywhere on a line.
+	topicKeyPattern = regexp.MustCompile(`(?i)topics?\w*["']?\s*(?::|=>|=)(?:[^=]|$)`)
+	// topicKeyPrefixPattern matches the text before a literal that sits in
+	// a topic key's value position, including later items of a list.
+	topicKeyPrefixPattern = regexp.MustCompile(`(?i)topics?\w*["']?\s*(?::|=>|=)\s*(?:` + listOpenerPattern + `|[\[(])?\s*(?:` + literalArgPattern + `\s*,\s*)*$`)
+	callArgPrefixPattern  = regexp.MustCompile(`^\s*(?:` + listOpenerPattern + `\s*(?:` + literalArgPattern + `\s*,\s*)*)?$`)
+	callArgLeadingPattern = regexp.MustCompile(`^\s*` + leadingArgsPattern + `(?:` + listOpenerPattern + `\s*(?:` + literalArgPattern + `\s*,\s*)*)?$`)
+)
+
+var (
+	jvmTopicHints   = []string{"topic", "kafka", "producerrecord", "subscri"}
+	jvmCallPattern  = regexp.MustCompile(`(?i)(?:\bsubscribe\w*|producerrecord(?:<[^>]*>|\[[^\]]*\])?|subscriptions?\.topics)\s*\(`)
+	defaultHints    = []string{"topic", "kafka", "subscribe", "produce"}
+	defaultCallExpr = regexp.MustCompile(`(?i)\b(?:subscribe|produce|produceasync|topicpartition)\s*\(`)
+)
+
+var (
+	kotlinLanguage = &sourceLanguage{
+		source: SourceKotlin,
+		syntax: literalSyntax{
+			lineComments:        []string{"//"},
+			blockComments:       true,
+			tripleQuoted:        true,
+			dollarInterpolation: true,
+		},
+		hints:       jvmTopicHints,
+		callPattern: jvmCallPattern,
+	}
+	scalaLanguage = &sourceLanguage{
+		source: SourceScala,
+		syntax: literalSyntax{
+			lineComments:        []string{"//"},
+			blockComments:       true,
+			tripleQuoted:        true,
+			dollarInterpolation: true,
+			prefixInterpolation: true,
+		},
+		hints:       jvmTopicHints,
+		callPattern: jvmCallPattern,
+	}
+	groovyLanguage = &sourceLanguage{
+		source: SourceGroovy,
+		syntax: literalSyntax{
+			lineComments:        []string{"//"},
+			blockComments:       true,
+			singleQuoted:        true,
+			tripleQuoted:        true,
+			dollarInterpolation: true,
+		},
+		hints:       jvmTopicHints,
+		callPattern: jvmCallPattern,
+	}
+	javascriptLanguage = &sourceLanguage{
+		source: SourceJavaScript,
+		syntax: literalSyntax{
+			lineComments:      []string{"//"},
+			blockComments:     true,
+			singleQuoted:      true,
+			backtickTemplates: true,
+		},
+		hints:       defaultHints,
+		callPattern: defaultCallExpr,
+	}
+	csharpLanguage = &sourceLanguage{
+		source: SourceCSharp,
+		syntax: literalSyntax{
+			lineComments:  []string{"//"},
+			blockComments: true,
+			csharpStrings: true,
+		},
+		hints:       defaultHints,
+		callPattern: defaultCallExpr,
+	}
+	rubyLanguage = &sourceLanguage{
+		source: SourceRuby,
+		syntax: literalSyntax{
+			lineComments:      []string{"#"},
+			singleQuoted:      true,
+			hashInterpolation: true,
+		},
+		hints:           append([]string{"deliver_message"}, defaultHints...),
+		callPattern:     regexp.MustCompile(`(?i)\b(?:subscribe|produce|deliver_message)(?:\s*\(|\s+)`),
+		symbolPattern:   regexp.MustCompile(`\btopic\s+:([A-Za-z0-9_]+)`),
+		symbolDirection: DirectionConsume,
+	}
+	rustLanguage = &sourceLanguage{
+		source: SourceRust,
+		syntax: literalSyntax{
+			lineComments:  []string{"//"},
+			blockComments: true,
+			rawStrings:    true,
+		},
+		hints:       []string{"topic", "kafka", "subscribe", "record::to"},
+		callPattern: regexp.MustCompile(`(?i)(?:\bsubscribe|record::to)\s*\(`),
+	}
+	elixirLanguage = &sourceLanguage{
+		source: SourceElixir,
+		syntax: literalSyntax{
+			lineComments:      []string{"#"},
+			singleQuoted:      true,
+			tripleQuoted:      true,
+			hashInterpolation: true,
+		},
+		hints:           []string{"topic", "kafka", "brod", "subscri", "produce"},
+		callPattern:     regexp.MustCompile(`(?i)(?:\bsubscribe\w*|\bproduce\w*|start_link_group_subscriber\w*)\s*\(`),
+		callLeadingArgs: true,
+	}
+)
+
+// sourceLanguages maps file extensions to literal-aware language scanners.
+var sourceLanguages = map[string]*sourceLanguage{
+	".kt":     kotlinLanguage,
+	".kts":    kotlinLanguage,
+	".scala":  scalaLanguage,
+	".sc":     scalaLanguage,
+	".groovy": groovyLanguage,
+	".js":     javascriptLanguage,
+	".mjs":    javascriptLanguage,
+	".ts":     javascriptLanguage,
+	".cs":     csharpLanguage,
+	".rb":     rubyLanguage,
+	".rs":     rustLanguage,
+	".ex":     elixirLanguage,
+	".exs":    elixirLanguage,
+}
+
+// maxTopicPositionPrefix bounds the text before a literal that
+// isTopicPosition matches, so that a long line with many literals is
+// scanned in linear time.
+const maxTopicPositionPrefix = 256
+
+// languageLine holds what scanLanguageFile checks about a whole line,
+// computed once for all literals on it.
+type languageLine struct {
+	text       string
+	hinted     bool
+	topicKey   bool
+	call       bool
+	logContext bool
+}
+
+func newLanguageLine(text string, lang *sourceLanguage) languageLine {
+	return languageLine{
+		text:       text,
+		hinted:     containsAnyHint(strings.ToLower(text), lang.hints),
+		topicKey:   topicKeyPattern.MatchString(text),
+		call:       lang.callPattern != nil && lang.callPattern.MatchString(text),
+		logContext: hasLogContext(text),
+	}
+}
+
+func scanLanguageFile(content []byte, lang *sourceLanguage) ([]Reference, error) {
+	lines := strings.Split(string(content), "\n")
+	refs := make([]Reference, 0)
+
+	var (
+		current     languageLine
+		currentLine int
+	)
+
+	for _, literal := range extractStringLiterals(content, lang.syntax) {
+		topic, template := literal.Value, ""
+		if literal.Interpolated {
+			converted, ok := interpolatedTemplate(literal.Value)
+			if !ok {
+				continue
+			}
+			topic, template = converted, converted
+		} else if !topicLiteralPattern.MatchString(literal.Value) {
+			continue
+		}
+
+		if literal.Line != currentLine {
+			line := ""
+			if literal.Line-1 < len(lines) {
+				line = lines[literal.Line-1]
+			}
+			current, currentLine = newLanguageLine(line, lang), literal.Line
+		}
+		line := current.text
+		if !current.hinted {
+			continue
+		}
+		if !isTopicPosition(current, literal.Start, lang) {
+			continue
+		}
+		if template == "" && !isLikelyTopic(literal.Value, line) {
+			continue
+		}
+
+		direction := DirectionUnknown
+		if literal.Start >= 1 && literal.Start-1 <= len(line) {
+			direction = classifyDirection(line[:literal.Start-1], line[literal.Start-1:])
+		}
+		ref := Reference{Topic: topic, Line: literal.Line, Column: literal.Column, Source: lang.source, Direction: direction, Template: template}
+		if template == "" {
+			ref.LiteralColumn = literal.verbatimColumn(content, topic)
+		}
+		ref.Confidence = referenceConfidence(ref, current.logContext)
+		refs = append(refs, ref)
+	}
+
+	if lang.symbolPattern != nil {
+		for i, line := range lines {
+			if matchLineComment(strings.TrimSpace(line), lang.syntax.lineComments) {
+				continue
+			}
+			for _, match := range lang.symbolPattern.FindAllStringSubmatch(line, -1) {
+				if isLikelyTopic(match[1], line) {
+					refs = append(refs, Reference{Topic: match[1], Line: i + 1, Source: lang.source, Direction: lang.symbolDirection})
+				}
+			}
+		}
+	}
+
+	return refs, nil
+}
+
+// isTopicPosition narrows which literals on a hinted line are topics. On a
+// line with a topic key only values of that key count; on a line with a known
+// client call only the call's topic argument counts; otherwise every literal
+// on the line is a candidate. Only the last maxTopicPositionPrefix bytes
+// before the literal are matched.
+func isTopicPosition(line languageLine, start int, lang *sourceLanguage) bool {
+	if start < 1 || start-1 > len(line.text) {
+		return true
+	}
+	prefix := line.text[max(0, start-1-maxTopicPositionPrefix) : start-1]
+
+	if line.topicKey {
+		return topicKeyPrefixPattern.MatchString(prefix)
+	}
+
+	if lang.callPattern != nil {
+		if locs := lang.callPattern.FindAllStringIndex(prefix, -1); len(locs) > 0 {
+			args := prefix[locs[len(locs)-1][1]:]
+			if lang.callLeadingArgs {
+				return callArgLeadingPattern.MatchString(args)
+			}
+			return callArgPrefixPattern.MatchString(args)
+		}
+		if line.call {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsAnyHint(line string, hints []string) bool {
+	for _, hint := range hints {
+		if strings.Contains(line, hint) {
+			return true
+		}
+	}
+	return false
+}
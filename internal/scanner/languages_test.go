@@ -0,0 +1,165 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRepoScannerScanJVMLanguages(t *testing.T) {
+	repoDir := t.TempDir()
+
+	mustWriteFile(t, filepath.Join(repoDir, "src", "Consumer.kt"), `package app
+
+const val ORDERS_TOPIC = "orders.v1"
+// consumer.subscribe(listOf("commented.topic"))
+fun run() {
+    consumer.subscribe(listOf("payments.v1", "refunds.$env"))
+    val query = """
+        SELECT * FROM topic_table
+    """
+}
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "src", "Stream.scala"), `object Stream {
+  val subscription = Subscriptions.topics("inventory.updates")
+  val record = new ProducerRecord[String, String](s"audit.$env", "key", "value")
+  val templated = s"ignored.$topic"
+}
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "build.gradle.kts"), `val kafkaTopic = "gradle.events"
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "src", "Routes.groovy"), `kafka.topic = 'shipping.v2'
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+
+	if result.FilesScanned != 4 {
+		t.Fatalf("FilesScanned = %d, want 4", result.FilesScanned)
+	}
+
+	wantSources := map[string]string{
+		"orders.v1":         SourceKotlin,
+		"payments.v1":       SourceKotlin,
+		"gradle.events":     SourceKotlin,
+		"inventory.updates": SourceScala,
+		"shipping.v2":       SourceGroovy,
+	}
+	for topic, source := range wantSources {
+		if !hasSource(result, topic, source) {
+			t.Fatalf("expected %s reference for %q, got %+v", source, topic, result.Topics[topic])
+		}
+	}
+
+	for _, topic := range []string{"commented.topic", "ignored.$topic", "audit.$env", "refunds.$env"} {
+		if _, ok := result.Topics[topic]; ok {
+			t.Fatalf("unexpected topic %q", topic)
+		}
+	}
+
+	ref := result.Topics["payments.v1"].Occurrences[0]
+	if ref.File != "src/Consumer.kt" || ref.Line != 6 {
+		t.Fatalf("unexpected payments.v1 location: %+v", ref)
+	}
+}
+
+func TestRepoScannerScanClientLanguages(t *testing.T) {
+	repoDir := t.TempDir()
+
+	mustWriteFile(t, filepath.Join(repoDir, "web", "consumer.ts"), `await consumer.subscribe({ topic: 'orders.created', fromBeginning: true })
+await producer.send({ topic: "orders.audit", messages: [{ value: 'hello.world' }] })
+const dynamic = `+"`orders.${env}`"+`
+// await consumer.subscribe({ topic: 'commented.out' })
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "web", "legacy.mjs"), `producer.produce('legacy.events', null, Buffer.from('payload.text'))
+consumer.subscribe(['legacy.one', 'legacy.two'])
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "Worker.cs"), `consumer.Subscribe(new[] { "billing.v1", "billing.v2" });
+await producer.ProduceAsync("billing.out", new Message<string, string> { Value = "body.text" });
+var templated = $"billing.{region}";
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "app", "karafka.rb"), `class KarafkaApp < Karafka::App
+  routes.draw do
+    topic :user_events do
+      consumer UserEventsConsumer
+    end
+    topic 'audit.log' do
+    end
+  end
+end
+kafka.deliver_message("hello there", topic: "greetings.v1")
+producer.produce("payload", topic: "shipments.#{env}")
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "rs", "main.rs"), `consumer.subscribe(&["telemetry.raw"]).expect("subscribe failed");
+let record = FutureRecord::to("telemetry.out").payload("data.bytes");
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "ex", "producer.ex"), `:brod.produce_sync(:kafka_client, "metrics.v1", :hash, key, "value.text")
+config :kaffe, consumer: [topics: ["metrics.in"]]
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+
+	wantSources := map[string]string{
+		"orders.created": SourceJavaScript,
+		"orders.audit":   SourceJavaScript,
+		"legacy.events":  SourceJavaScript,
+		"legacy.one":     SourceJavaScript,
+		"legacy.two":     SourceJavaScript,
+		"billing.v1":     SourceCSharp,
+		"billing.v2":     SourceCSharp,
+		"billing.out":    SourceCSharp,
+		"user_events":    SourceRuby,
+		"audit.log":      SourceRuby,
+		"greetings.v1":   SourceRuby,
+		"telemetry.raw":  SourceRust,
+		"telemetry.out":  SourceRust,
+		"metrics.v1":     SourceElixir,
+		"metrics.in":     SourceElixir,
+	}
+	for topic, source := range wantSources {
+		if !hasSource(result, topic, source) {
+			t.Fatalf("expected %s reference for %q, got %+v", source, topic, result.Topics[topic])
+		}
+	}
+
+	unwanted := []string{
+		"hello.world", "payload.text", "body.text", "hello there", "payload",
+		"subscribe failed", "data.bytes", "value.text", "commented.out",
+	}
+	for _, topic := range unwanted {
+		if _, ok := result.Topics[topic]; ok {
+			t.Fatalf("unexpected topic %q", topic)
+		}
+	}
+	for topic, ref := range result.Topics {
+		if strings.ContainsAny(topic, "$#") {
+			t.Fatalf("unexpected interpolated topic %q", topic)
+		}
+		if strings.ContainsAny(topic, "{}") && ref.Occurrences[0].Template != topic {
+			t.Fatalf("templated topic %q should be recorded as a template", topic)
+		}
+	}
+	if _, ok := result.Topics["shipments.{env}"]; !ok {
+		t.Fatalf("expected Ruby interpolation to be recorded as template shipments.{env}")
+	}
+}
+
+func TestScanLanguageFileLongLine(t *testing.T) {
+	// Minified sources put everything on one line; only the text just
+	// before each literal decides whether it is a topic.
+	content := []byte(strings.Repeat(`log('x.y');`, 500) + `consumer.subscribe({ topic: 'orders.created' });`)
+
+	refs, err := scanLanguageFile(content, javascriptLanguage)
+	if err != nil {
+		t.Fatalf("scanLanguageFile() error = %v", err)
+	}
+	if len(refs) != 1 || refs[0].Topic != "orders.created" || refs[0].Column != 5530 || refs[0].Direction != DirectionConsume {
+		t.Fatalf("references = %#v, want orders.created at column 5530", refs)
+	}
+}
@@ -0,0 +1,320 @@
+package scanner
+
+import "strings"
+
+// literalSyntax describes how a language spells comments and string literals.
+type literalSyntax struct {
+	lineComments  []string
+	blockComments bool
+	// singleQuoted treats '...' as a string; otherwise it is a char literal.
+	singleQuoted bool
+	tripleQuoted bool
+	// backtickTemplates treats `...` as a template literal with ${expr}.
+	backtickTemplates bool
+	// dollarInterpolation marks "$name" / "${expr}" as template placeholders.
+	// When prefixInterpolation is set it only applies to prefixed literals
+	// such as Scala's s"..." and f"...".
+	dollarInterpolation bool
+	prefixInterpolation bool
+	// singleQuotedInterpolation enables placeholders in '...' strings.
+	singleQuotedInterpolation bool
+	// hashInterpolation marks "#{expr}" placeholders (Ruby, Elixir).
+	hashInterpolation bool
+	// csharpStrings enables $"{expr}" interpolated and @"..." verbatim strings.
+	csharpStrings bool
+	// rawStrings enables Rust r"..." and r#"..."# raw strings.
+	rawStrings bool
+}
+
+// stringLiteral is a string literal found in source code.
+type stringLiteral struct {
+	Value string
+	Line  int
+	// Column is the 1-based byte column of the first character of Value.
+	Column int
+	// Start is the 1-based byte column of the opening delimiter.
+	Start        int
+	Interpolated bool
+	// Offset and End delimit the whole literal, quotes included, in bytes.
+	Offset int
+	End    int
+}
+
+// verbatimColumn returns the literal's Column when its source text between
+// plain quotes is exactly value, so the span can be rewritten in place, and
+// 0 otherwise (escapes, prefixes, interpolation).
+func (l stringLiteral) verbatimColumn(content []byte, value string) int {
+	if l.Interpolated || l.Offset < 0 || l.Offset >= len(content) {
+		return 0
+	}
+	quote := content[l.Offset]
+	if quote != '"' && quote != '\'' && quote != '`' {
+		return 0
+	}
+	start := l.Offset + l.Column - l.Start
+	end := start + len(value)
+	if start <= l.Offset || end >= l.End || end >= len(content) || string(content[start:end]) != value || content[end] != quote {
+		return 0
+	}
+	return l.Column
+}
+
+// literalMode controls how the body of a single literal is read.
+type literalMode struct {
+	// open is the opening delimiter at the literal's start; closing ends it.
+	open    string
+	closing string
+	raw     bool
+	// doubledQuote treats a repeated quote as an escaped quote (C# @"").
+	doubledQuote bool
+	dollar       bool
+	dollarBrace  bool
+	hash         bool
+	brace        bool
+}
+
+// extractStringLiterals tokenizes content just enough to find string
+// literals, skipping comments. It is intentionally forgiving: malformed input
+// ends the current literal at end of file rather than failing the scan.
+func extractStringLiterals(content []byte, syntax literalSyntax) []stringLiteral {
+	src := string(content)
+	literals := make([]stringLiteral, 0)
+
+	line := 1
+	lineStart := 0
+	advance := func(from, to int) {
+		for k := from; k < to && k < len(src); k++ {
+			if src[k] == '\n' {
+				line++
+				lineStart = k + 1
+			}
+		}
+	}
+
+	i := 0
+	for i < len(src) {
+		if matchLineComment(src[i:], syntax.lineComments) {
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				break
+			}
+			i += end
+			continue
+		}
+		if syntax.blockComments && strings.HasPrefix(src[i:], "/*") {
+			end := strings.Index(src[i+2:], "*/")
+			next := len(src)
+			if end >= 0 {
+				next = i + 2 + end + 2
+			}
+			advance(i, next)
+			i = next
+			continue
+		}
+
+		c := src[i]
+		if c == '\n' {
+			line++
+			lineStart = i + 1
+			i++
+			continue
+		}
+		if c == '\'' && !syntax.singleQuoted {
+			i = skipCharLiteral(src, i)
+			continue
+		}
+		if c != '"' && c != '\'' && (c != '`' || !syntax.backtickTemplates) {
+			i++
+			continue
+		}
+
+		mode := literalModeAt(src, i, syntax)
+		offset := i
+		startLine := line
+		start := i - lineStart + 1
+		startColumn := start + len(mode.open)
+		value, interpolated, next := readStringLiteral(src, i+len(mode.open), mode)
+		advance(i, next)
+		i = next
+
+		literals = append(literals, stringLiteral{
+			Value:        value,
+			Line:         startLine,
+			Column:       startColumn,
+			Start:        start,
+			Interpolated: interpolated,
+			Offset:       offset,
+			End:          next,
+		})
+	}
+
+	return literals
+}
+
+// literalModeAt decides how to read the literal whose opening quote is at i,
+// looking back at any prefix (s"", $"", @"", r#"").
+func literalModeAt(src string, i int, syntax literalSyntax) literalMode {
+	c := src[i]
+	mode := literalMode{open: string(c), closing: string(c)}
+
+	if c == '`' {
+		mode.dollarBrace = true
+		return mode
+	}
+
+	prefixed := i > 0 && isIdentByte(src[i-1])
+	if syntax.dollarInterpolation && (!syntax.prefixInterpolation || prefixed) && (c == '"' || syntax.singleQuotedInterpolation) {
+		mode.dollar = true
+	}
+	if syntax.hashInterpolation && c == '"' {
+		mode.hash = true
+	}
+
+	if syntax.csharpStrings && c == '"' {
+		prefix := csharpPrefix(src, i)
+		mode.brace = strings.Contains(prefix, "$")
+		if strings.Contains(prefix, "@") {
+			mode.raw = true
+			mode.doubledQuote = true
+		}
+	}
+
+	if syntax.rawStrings && c == '"' {
+		hashes := 0
+		for k := i - 1; k >= 0 && src[k] == '#'; k-- {
+			hashes++
+		}
+		r := i - hashes - 1
+		if r >= 0 && src[r] == 'r' && (r == 0 || !isIdentByte(src[r-1]) || (src[r-1] == 'b' && (r < 2 || !isIdentByte(src[r-2])))) {
+			mode.raw = true
+			mode.closing = `"` + strings.Repeat("#", hashes)
+			return mode
+		}
+	}
+
+	if syntax.tripleQuoted && strings.HasPrefix(src[i:], strings.Repeat(string(c), 3)) {
+		mode.open = strings.Repeat(string(c), 3)
+		mode.closing = mode.open
+		mode.raw = true
+	}
+
+	return mode
+}
+
+// readStringLiteral reads until the closing delimiter and returns the decoded
+// value, whether it contained placeholders and the index after the literal.
+func readStringLiteral(src string, start int, mode literalMode) (string, bool, int) {
+	var value strings.Builder
+	interpolated := false
+	closing := mode.closing
+	multiline := len(closing) > 1 || closing == "`" || mode.raw
+
+	i := start
+	for i < len(src) {
+		if mode.doubledQuote && strings.HasPrefix(src[i:], closing+closing) {
+			value.WriteString(closing)
+			i += 2 * len(closing)
+			continue
+		}
+		if strings.HasPrefix(src[i:], closing) {
+			return value.String(), interpolated, i + len(closing)
+		}
+
+		c := src[i]
+		switch {
+		case c == '\n' && !multiline:
+			// Unterminated single-line literal.
+			return value.String(), interpolated, i
+		case c == '\\' && !mode.raw && i+1 < len(src):
+			value.WriteByte(src[i+1])
+			i += 2
+			continue
+		case (mode.dollar || mode.dollarBrace) && c == '$' && i+1 < len(src) && src[i+1] == '{',
+			mode.hash && c == '#' && i+1 < len(src) && src[i+1] == '{':
+			interpolated = true
+			end := matchingBrace(src, i+1)
+			value.WriteString(src[i:end])
+			i = end
+			continue
+		case mode.dollar && c == '$' && i+1 < len(src) && isIdentStartByte(src[i+1]):
+			interpolated = true
+		case mode.brace && c == '{':
+			if i+1 < len(src) && src[i+1] == '{' {
+				value.WriteByte('{')
+				i += 2
+				continue
+			}
+			interpolated = true
+			end := matchingBrace(src, i)
+			value.WriteString(src[i:end])
+			i = end
+			continue
+		case mode.brace && c == '}' && i+1 < len(src) && src[i+1] == '}':
+			value.WriteByte('}')
+			i += 2
+			continue
+		}
+
+		value.WriteByte(c)
+		i++
+	}
+
+	return value.String(), interpolated, len(src)
+}
+
+func csharpPrefix(src string, i int) string {
+	start := i
+	for start > 0 && (src[start-1] == '$' || src[start-1] == '@') && i-start < 2 {
+		start--
+	}
+	return src[start:i]
+}
+
+// matchingBrace returns the index after the brace matching src[open].
+func matchingBrace(src string, open int) int {
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch src[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case '\n':
+			return i
+		}
+	}
+	return len(src)
+}
+
+func skipCharLiteral(src string, i int) int {
+	if i+1 < len(src) && src[i+1] == '\\' {
+		if end := strings.IndexByte(src[i+2:], '\''); end >= 0 && end < 10 {
+			return i + 2 + end + 1
+		}
+		return i + 1
+	}
+	if i+2 < len(src) && src[i+2] == '\'' {
+		return i + 3
+	}
+	return i + 1
+}
+
+func matchLineComment(src string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.HasPrefix(src, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func isIdentByte(c byte) bool {
+	return isIdentStartByte(c) || (c >= '0' && c <= '9')
+}
+
+func isIdentStartByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
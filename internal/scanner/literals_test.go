@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractStringLiterals(t *testing.T) {
+	tests := []struct {
+		name    string
+		syntax  literalSyntax
+		content string
+		want    []stringLiteral
+	}{
+		{
+			name:   "kotlin templates and raw strings",
+			syntax: kotlinLanguage.syntax,
+			content: `val a = "orders.v1" // "commented.out"
+val b = "orders.${env}.v1"
+/* "block.comment" */ val c = """raw.topic"""
+val d = 'x'
+val e = "events.$suffix"
+`,
+			want: []stringLiteral{
+				{Value: "orders.v1", Line: 1, Column: 10, Start: 9},
+				{Value: "orders.${env}.v1", Line: 2, Column: 10, Start: 9, Interpolated: true},
+				{Value: "raw.topic", Line: 3, Column: 34, Start: 31},
+				{Value: "events.$suffix", Line: 5, Column: 10, Start: 9, Interpolated: true},
+			},
+		},
+		{
+			name:   "scala prefixed interpolation",
+			syntax: scalaLanguage.syntax,
+			content: `val a = s"orders.$env"
+val b = "price.$usd"
+val c = f"""multi
+line"""
+`,
+			want: []stringLiteral{
+				{Value: "orders.$env", Line: 1, Column: 11, Start: 10, Interpolated: true},
+				{Value: "price.$usd", Line: 2, Column: 10, Start: 9},
+				{Value: "multi\nline", Line: 3, Column: 13, Start: 10},
+			},
+		},
+		{
+			name:   "groovy single quotes are plain strings",
+			syntax: groovyLanguage.syntax,
+			content: `def a = 'orders.$x'
+def b = "escaped \"q\""
+`,
+			want: []stringLiteral{
+				{Value: "orders.$x", Line: 1, Column: 10, Start: 9},
+				{Value: `escaped "q"`, Line: 2, Column: 10, Start: 9},
+			},
+		},
+		{
+			name:    "javascript templates",
+			syntax:  javascriptLanguage.syntax,
+			content: "const a = 'orders.v1'\nconst b = `events.${env}`\nconst c = `$plain`\n",
+			want: []stringLiteral{
+				{Value: "orders.v1", Line: 1, Column: 12, Start: 11},
+				{Value: "events.${env}", Line: 2, Column: 12, Start: 11, Interpolated: true},
+				{Value: "$plain", Line: 3, Column: 12, Start: 11},
+			},
+		},
+		{
+			name:   "csharp interpolated and verbatim",
+			syntax: csharpLanguage.syntax,
+			content: `var a = $"orders.{env}";
+var b = @"C:\path ""q""";
+var c = $"{{literal}}";
+var d = 'x';
+`,
+			want: []stringLiteral{
+				{Value: "orders.{env}", Line: 1, Column: 11, Start: 10, Interpolated: true},
+				{Value: `C:\path "q"`, Line: 2, Column: 11, Start: 10},
+				{Value: "{literal}", Line: 3, Column: 11, Start: 10},
+			},
+		},
+		{
+			name:   "ruby hash interpolation and comments",
+			syntax: rubyLanguage.syntax,
+			content: `a = "orders.#{env}" # "commented"
+b = 'plain.#{x}'
+`,
+			want: []stringLiteral{
+				{Value: "orders.#{env}", Line: 1, Column: 6, Start: 5, Interpolated: true},
+				{Value: "plain.#{x}", Line: 2, Column: 6, Start: 5},
+			},
+		},
+		{
+			name:    "rust raw strings and lifetimes",
+			syntax:  rustLanguage.syntax,
+			content: "fn f<'a>(x: &'a str) { let t = r#\"raw \"quoted\"\"#; let c = '\"'; }\n",
+			want: []stringLiteral{
+				{Value: `raw "quoted"`, Line: 1, Column: 35, Start: 34},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractStringLiterals([]byte(tt.content), tt.syntax)
+			for i := range got {
+				if got[i].Offset >= got[i].End || got[i].End > len(tt.content) {
+					t.Fatalf("literal %d span = [%d,%d)", i, got[i].Offset, got[i].End)
+				}
+				got[i].Offset, got[i].End = 0, 0
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("extractStringLiterals() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SourceManifest marks declarations loaded from a desired-state topic manifest.
+const SourceManifest = "manifest"
+
+// TopicDeclaration is a declared (desired) topic definition found in a
+// manifest or infrastructure-as-code file. Zero values mean "not declared".
+type TopicDeclaration struct {
+	Topic             string            `json:"topic"`
+	Repo              string            `json:"repo,omitempty"`
+	File              string            `json:"file"`
+	Line              int               `json:"line,omitempty"`
+	Source            string            `json:"source"`
+	Partitions        int               `json:"partitions,omitempty"`
+	ReplicationFactor int               `json:"replication_factor,omitempty"`
+	Config            map[string]string `json:"config,omitempty"`
+}
+
+// LoadManifest parses a desired-state topic manifest:
+//
+//	topics:
+//	  - name: orders.events
+//	    partitions: 12
+//	    replication_factor: 3
+//	    configs:
+//	      retention.ms: "604800000"
+func LoadManifest(path string) ([]TopicDeclaration, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest %q: %w", path, err)
+	}
+
+	decls, err := parseManifest(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse manifest %q: %w", path, err)
+	}
+	for i := range decls {
+		decls[i].File = path
+	}
+
+	return decls, nil
+}
+
+func parseManifest(content []byte) ([]TopicDeclaration, error) {
+	docs := parseYAMLDocuments(content)
+	if len(docs) == 0 {
+		return nil, errors.New("manifest is empty")
+	}
+
+	decls := make([]TopicDeclaration, 0)
+	for _, doc := range docs {
+		topics := doc.get("topics")
+		if topics == nil || topics.Kind != yamlSequence {
+			return nil, fmt.Errorf("line %d: expected topics list", doc.Line)
+		}
+
+		for _, item := range topics.Items {
+			if item.Kind != yamlMapping {
+				return nil, fmt.Errorf("line %d: expected topic mapping", item.Line)
+			}
+
+			name := strings.TrimSpace(item.get("name").scalar())
+			if name == "" {
+				return nil, fmt.Errorf("line %d: topic name is required", item.Line)
+			}
+
+			decl := TopicDeclaration{
+				Topic:  name,
+				Line:   item.Line,
+				Source: SourceManifest,
+			}
+
+			partitions, err := manifestInt(item, "partitions")
+			if err != nil {
+				return nil, err
+			}
+			replicationFactor, err := manifestInt(item, "replication_factor", "replicationFactor", "replicas")
+			if err != nil {
+				return nil, err
+			}
+			decl.Partitions = partitions
+			decl.ReplicationFactor = replicationFactor
+			decl.Config = manifestConfig(item, "configs", "config")
+
+			decls = append(decls, decl)
+		}
+	}
+
+	return decls, nil
+}
+
+func manifestInt(node *yamlNode, keys ...string) (int, error) {
+	for _, key := range keys {
+		child := node.get(key)
+		if child == nil {
+			continue
+		}
+		value := strings.TrimSpace(child.scalar())
+		if value == "" {
+			return 0, nil
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: parse %s: %w", child.Line, key, err)
+		}
+		return parsed, nil
+	}
+	return 0, nil
+}
+
+func manifestConfig(node *yamlNode, keys ...string) map[string]string {
+	for _, key := range keys {
+		child := node.get(key)
+		if child == nil || child.Kind != yamlMapping {
+			continue
+		}
+		config := make(map[string]string, len(child.Keys))
+		for _, configKey := range child.Keys {
+			config[configKey] = child.Fields[configKey].scalar()
+		}
+		if len(config) == 0 {
+			return nil
+		}
+		return config
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topics.yaml")
+	mustWriteFile(t, path, `# desired state
+topics:
+  - name: orders.events
+    partitions: 12
+    replication_factor: 3
+    configs:
+      retention.ms: "604800000"
+      cleanup.policy: delete
+  - name: "payments.v1"
+    replicationFactor: 2
+`)
+
+	decls, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if len(decls) != 2 {
+		t.Fatalf("declarations = %d, want 2", len(decls))
+	}
+
+	orders := decls[0]
+	if orders.Topic != "orders.events" || orders.Line != 3 || orders.File != path || orders.Source != SourceManifest {
+		t.Fatalf("unexpected orders declaration: %+v", orders)
+	}
+	if orders.Partitions != 12 || orders.ReplicationFactor != 3 {
+		t.Fatalf("unexpected orders sizing: %+v", orders)
+	}
+	if orders.Config["retention.ms"] != "604800000" || orders.Config["cleanup.policy"] != "delete" {
+		t.Fatalf("unexpected orders config: %#v", orders.Config)
+	}
+
+	payments := decls[1]
+	if payments.Topic != "payments.v1" || payments.Partitions != 0 || payments.ReplicationFactor != 2 || payments.Config != nil {
+		t.Fatalf("unexpected payments declaration: %+v", payments)
+	}
+}
+
+func TestLoadManifestErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "empty", content: "", wantErr: "manifest is empty"},
+		{name: "missing topics", content: "other: value\n", wantErr: "expected topics list"},
+		{name: "missing name", content: "topics:\n  - partitions: 3\n", wantErr: "line 2: topic name is required"},
+		{name: "bad partitions", content: "topics:\n  - name: a\n    partitions: many\n", wantErr: "line 3: parse partitions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "topics.yaml")
+			mustWriteFile(t, path, tt.content)
+
+			_, err := LoadManifest(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("LoadManifest() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadManifest(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatalf("expected error for missing manifest")
+	}
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"sort"
+	"strings"
+)
+
+// MergeResults combines the scans of several repositories, pairing labels
+// and results by index. Every reference, pattern, declaration and
+// suppression keeps the label of the repository it came from in its Repo
+// field, since file paths are only unique within a repository.
+func MergeResults(labels []string, results []*Result) *Result {
+	merged := &Result{
+		RepoPath:     strings.Join(labels, ", "),
+		Repos:        append([]string(nil), labels...),
+		RepoRoots:    make([]string, 0, len(results)),
+		Topics:       make(map[string]*TopicReference),
+		SkippedFiles: make(map[string]int),
+		SkippedDirs:  make(map[string]int),
+	}
+
+	for idx, result := range results {
+		repo := labels[idx]
+		merged.RepoRoots = append(merged.RepoRoots, result.RepoPath)
+		merged.FilesScanned += result.FilesScanned
+		merged.CachedFiles += result.CachedFiles
+		for reason, count := range result.SkippedFiles {
+			merged.SkippedFiles[reason] += count
+		}
+		for reason, count := range result.SkippedDirs {
+			merged.SkippedDirs[reason] += count
+		}
+
+		for topic, topicRef := range result.Topics {
+			target, exists := merged.Topics[topic]
+			if !exists {
+				target = &TopicReference{Topic: topic}
+				merged.Topics[topic] = target
+			}
+			for _, ref := range topicRef.Occurrences {
+				ref.Repo = repo
+				target.Occurrences = append(target.Occurrences, ref)
+			}
+		}
+		for _, decl := range result.Declarations {
+			decl.Repo = repo
+			merged.Declarations = append(merged.Declarations, decl)
+		}
+		for _, pattern := range result.Patterns {
+			pattern.Repo = repo
+			merged.Patterns = append(merged.Patterns, pattern)
+		}
+		for _, suppression := range result.Suppressions {
+			suppression.Repo = repo
+			merged.Suppressions = append(merged.Suppressions, suppression)
+		}
+		for _, ref := range result.Suppressed {
+			ref.Repo = repo
+			merged.Suppressed = append(merged.Suppressed, ref)
+		}
+	}
+
+	for _, topicRef := range merged.Topics {
+		sort.SliceStable(topicRef.Occurrences, func(i, j int) bool {
+			return topicRef.Occurrences[i].Repo < topicRef.Occurrences[j].Repo
+		})
+	}
+	return merged
+}
@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMergeResultsKeepsRepoAttribution(t *testing.T) {
+	ordersDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(ordersDir, "config", "app.yaml"), "topic: orders.created\n")
+	mustWriteFile(t, filepath.Join(ordersDir, "topics.tf"), `resource "kafka_topic" "orders" {
+  name       = "orders.created"
+  partitions = 6
+}
+`)
+	billingDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(billingDir, "config", "app.yaml"), "topic: orders.created\ntopics: [billing.replay] # kafkaspectre:ignore reason=manual\n")
+
+	s := NewRepoScanner()
+	orders, err := s.Scan(context.Background(), ordersDir)
+	if err != nil {
+		t.Fatalf("Scan(orders) error = %v", err)
+	}
+	billing, err := s.Scan(context.Background(), billingDir)
+	if err != nil {
+		t.Fatalf("Scan(billing) error = %v", err)
+	}
+
+	merged := MergeResults([]string{"orders", "billing"}, []*Result{orders, billing})
+
+	if merged.RepoPath != "orders, billing" || !reflect.DeepEqual(merged.Repos, []string{"orders", "billing"}) {
+		t.Fatalf("RepoPath = %q, Repos = %#v", merged.RepoPath, merged.Repos)
+	}
+	if !reflect.DeepEqual(merged.RepoRoots, []string{orders.RepoPath, billing.RepoPath}) {
+		t.Fatalf("RepoRoots = %#v", merged.RepoRoots)
+	}
+	if merged.FilesScanned != orders.FilesScanned+billing.FilesScanned {
+		t.Fatalf("FilesScanned = %d", merged.FilesScanned)
+	}
+
+	refs := merged.Topics["orders.created"].Occurrences
+	var repos []string
+	for _, ref := range refs {
+		if ref.File != "config/app.yaml" && ref.File != "topics.tf" {
+			t.Fatalf("unexpected reference %#v", ref)
+		}
+		repos = append(repos, ref.Repo)
+	}
+	if len(repos) < 2 || repos[0] != "billing" || repos[len(repos)-1] != "orders" {
+		t.Fatalf("reference repos = %v, want billing first and orders last", repos)
+	}
+
+	if len(merged.Declarations) != 1 || merged.Declarations[0].Repo != "orders" {
+		t.Fatalf("Declarations = %#v", merged.Declarations)
+	}
+	if len(merged.Suppressed) != 1 || merged.Suppressed[0].Repo != "billing" || merged.Suppressions[0].Repo != "billing" {
+		t.Fatalf("Suppressed = %#v, Suppressions = %#v", merged.Suppressed, merged.Suppressions)
+	}
+	if orders.Topics["orders.created"].Occurrences[0].Repo != "" {
+		t.Fatalf("MergeResults must not modify its inputs")
+	}
+}
@@ -0,0 +1,185 @@
+package scanner
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PatternReference is a regex topic subscription, such as
+// subscribe(Pattern.compile("orders\\..*")) or topics.regex. Patterns are
+// matched against cluster topic names by check rather than reported as
+// topics themselves.
+type PatternReference struct {
+	Pattern   string `json:"pattern"`
+	Repo      string `json:"repo,omitempty"`
+	File      string `json:"file"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	Source    string `json:"source"`
+	Direction string `json:"direction,omitempty"`
+}
+
+var (
+	// topicPatternKeyPattern matches config keys holding a subscription
+	// regex: topics.regex (Kafka Connect), topic.pattern, topicPattern.
+	topicPatternKeyPattern   = regexp.MustCompile(`(?i)topics?[._-]?(?:regex|pattern)s?$`)
+	patternConfigLinePattern = regexp.MustCompile(`(?i)^\s*["']?([A-Za-z0-9_.-]*topics?[._-]?(?:regex|pattern)s?)["']?\s*[:=]\s*(.*?)\s*,?\s*$`)
+
+	// The prefix patterns match the code immediately before a literal that
+	// makes it a subscription regex.
+	patternCompilePrefix     = regexp.MustCompile(`Pattern\s*\.\s*compile\s*\(\s*$`)
+	patternAttributePrefixes = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\btopic_?pattern\s*[=:]\s*$`),
+		regexp.MustCompile(`(?i)\bsubscribe\s*\(.*\bpattern\s*=\s*$`),
+	}
+	// jsRegexTopicPattern matches kafkajs subscribe({ topic: /orders\..*/ }).
+	jsRegexTopicPattern = regexp.MustCompile(`\btopics?\s*:\s*\[?\s*/((?:[^/\\\n]|\\.)+)/[a-z]*`)
+)
+
+var pythonLiteralSyntax = literalSyntax{
+	lineComments: []string{"#"},
+	singleQuoted: true,
+	tripleQuoted: true,
+}
+
+func isTopicPatternKey(key string) bool {
+	return topicPatternKeyPattern.MatchString(key)
+}
+
+// scanFilePatterns returns subscription patterns for a file already
+// classified by detectScanMode.
+func scanFilePatterns(mode scanMode, path string, content []byte) []PatternReference {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch mode {
+	case scanConfig:
+		return scanConfigPatterns(content)
+	case scanEnv:
+		return scanEnvPatterns(content)
+	case scanProperties:
+		return scanKeyValuePatterns(parseProperties(content), SourceProperties)
+	case scanHOCON:
+		return scanKeyValuePatterns(parseStructuredConfig(content, hoconDialect), SourceHOCON)
+	case scanTOML:
+		return scanKeyValuePatterns(parseStructuredConfig(content, tomlDialect), SourceTOML)
+	case scanINI:
+		return scanKeyValuePatterns(parseINI(content), SourceINI)
+	case scanSource:
+		if ext == ".py" {
+			return scanSourcePatterns(content, pythonLiteralSyntax, SourceRegex)
+		}
+		return scanSourcePatterns(content, javaLiteralSyntax, SourceRegex)
+	case scanLanguage:
+		lang := sourceLanguages[ext]
+		patterns := scanSourcePatterns(content, lang.syntax, lang.source)
+		if lang.syntax.backtickTemplates {
+			patterns = append(patterns, scanJSRegexPatterns(content, lang.source)...)
+		}
+		return patterns
+	default:
+		return nil
+	}
+}
+
+func scanConfigPatterns(content []byte) []PatternReference {
+	patterns := make([]PatternReference, 0)
+	for i, line := range splitConfigLines(content) {
+		match := patternConfigLinePattern.FindStringSubmatch(line)
+		if len(match) != 3 {
+			continue
+		}
+		if pattern := unquoteYAMLScalar(stripYAMLComment(match[2])); pattern != "" {
+			patterns = append(patterns, PatternReference{Pattern: pattern, Line: i + 1, Source: SourceYAMLJSON, Direction: DirectionConsume})
+		}
+	}
+	return patterns
+}
+
+func scanEnvPatterns(content []byte) []PatternReference {
+	patterns := make([]PatternReference, 0)
+	for i, line := range splitConfigLines(content) {
+		match := envLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if len(match) != 3 || !isTopicPatternKey(match[1]) {
+			continue
+		}
+		if pattern := unquoteYAMLScalar(stripInlineComment(match[2])); pattern != "" {
+			patterns = append(patterns, PatternReference{Pattern: pattern, Line: i + 1, Source: SourceEnv, Direction: DirectionConsume})
+		}
+	}
+	return patterns
+}
+
+func scanKeyValuePatterns(entries []propertyEntry, source string) []PatternReference {
+	patterns := make([]PatternReference, 0)
+	for _, entry := range entries {
+		if !isTopicPatternKey(entry.Key) {
+			continue
+		}
+		if pattern := unquoteYAMLScalar(entry.Value); pattern != "" {
+			patterns = append(patterns, PatternReference{Pattern: pattern, Line: entry.Line, Source: source, Direction: DirectionConsume})
+		}
+	}
+	return patterns
+}
+
+// maxPatternPrefix bounds how much of the line before a literal is searched
+// for subscribe/Pattern.compile context.
+const maxPatternPrefix = 512
+
+// scanSourcePatterns finds literals passed to Pattern.compile, topicPattern
+// (Spring @KafkaListener) and subscribe(pattern=...), plus '^'-prefixed
+// literals on subscribe lines, which librdkafka clients treat as regexes.
+func scanSourcePatterns(content []byte, syntax literalSyntax, source string) []PatternReference {
+	patterns := make([]PatternReference, 0)
+	for _, literal := range extractStringLiterals(content, syntax) {
+		if literal.Interpolated || literal.Value == "" || strings.Contains(literal.Value, "${") {
+			continue
+		}
+		// Only the line up to the literal matters, and at most
+		// maxPatternPrefix bytes of it so minified sources stay linear.
+		windowStart := max(0, literal.Offset-maxPatternPrefix)
+		lineStart := windowStart + bytes.LastIndexByte(content[windowStart:literal.Offset], '\n') + 1
+		prefix := string(content[lineStart:literal.Offset])
+
+		if isSourcePatternPosition(literal.Value, prefix) {
+			patterns = append(patterns, PatternReference{Pattern: literal.Value, Line: literal.Line, Column: literal.Column, Source: source, Direction: DirectionConsume})
+		}
+	}
+	return patterns
+}
+
+func scanJSRegexPatterns(content []byte, source string) []PatternReference {
+	patterns := make([]PatternReference, 0)
+	for i, line := range splitConfigLines(content) {
+		if !strings.Contains(strings.ToLower(line), "subscribe") {
+			continue
+		}
+		for _, match := range jsRegexTopicPattern.FindAllStringSubmatchIndex(line, -1) {
+			patterns = append(patterns, PatternReference{Pattern: line[match[2]:match[3]], Line: i + 1, Column: match[2] + 1, Source: source, Direction: DirectionConsume})
+		}
+	}
+	return patterns
+}
+
+func isSourcePatternPosition(value, prefix string) bool {
+	lower := strings.ToLower(prefix)
+	if strings.HasPrefix(value, "^") && strings.Contains(lower, "subscribe") {
+		return true
+	}
+	// Every remaining form names a pattern; skip the regexes otherwise.
+	if !strings.Contains(lower, "pattern") {
+		return false
+	}
+	// Pattern.compile is used for far more than subscriptions, so it only
+	// counts on lines that mention topics or subscribing.
+	if patternCompilePrefix.MatchString(prefix) {
+		return strings.Contains(lower, "topic") || strings.Contains(lower, "subscribe")
+	}
+	for _, pattern := range patternAttributePrefixes {
+		if pattern.MatchString(prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRepoScannerScanPatterns(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "src", "OrderConsumer.java"), `class OrderConsumer {
+    private static final Pattern TOPIC_PATTERN = Pattern.compile("orders\\..*");
+    private static final Pattern DIGITS = Pattern.compile("\\d+");
+
+    @KafkaListener(topicPattern = "payments-.*", groupId = "billing")
+    void onPayment(String payload) {}
+
+    void run() { consumer.subscribe(Pattern.compile("audit\\.v[0-9]+")); }
+}
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "worker.py"), `consumer.subscribe(["^refunds\\..*"])
+consumer.subscribe(pattern="shipments.*")
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "web", "consumer.ts"), `await consumer.subscribe({ topic: /inventory\.(eu|us)/, fromBeginning: true })
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "connect", "sink.properties"), `name=s3-sink
+topics.regex=clicks\\..*
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "config", "app.yaml"), `kafka:
+  topic-pattern: "metrics\\..*"
+  topic: metrics.raw
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	want := []PatternReference{
+		{Pattern: `metrics\..*`, File: "config/app.yaml", Line: 2, Source: SourceYAMLJSON, Direction: DirectionConsume},
+		{Pattern: `clicks\..*`, File: "connect/sink.properties", Line: 2, Source: SourceProperties, Direction: DirectionConsume},
+		{Pattern: `orders\..*`, File: "src/OrderConsumer.java", Line: 2, Column: 67, Source: SourceRegex, Direction: DirectionConsume},
+		{Pattern: `payments-.*`, File: "src/OrderConsumer.java", Line: 5, Column: 36, Source: SourceSpring, Direction: DirectionConsume},
+		{Pattern: `audit\.v[0-9]+`, File: "src/OrderConsumer.java", Line: 8, Column: 54, Source: SourceRegex, Direction: DirectionConsume},
+		{Pattern: `inventory\.(eu|us)`, File: "web/consumer.ts", Line: 1, Column: 36, Source: SourceJavaScript, Direction: DirectionConsume},
+		{Pattern: `^refunds\..*`, File: "worker.py", Line: 1, Column: 22, Source: SourceRegex, Direction: DirectionConsume},
+		{Pattern: `shipments.*`, File: "worker.py", Line: 2, Column: 29, Source: SourceRegex, Direction: DirectionConsume},
+	}
+	if !reflect.DeepEqual(result.Patterns, want) {
+		t.Fatalf("patterns = %#v, want %#v", result.Patterns, want)
+	}
+
+	if _, ok := result.Topics["clicks"]; ok {
+		t.Fatalf("pattern value should not be reported as a topic")
+	}
+	if _, ok := result.Topics["metrics.raw"]; !ok {
+		t.Fatalf("expected plain topic key next to the pattern key to be reported")
+	}
+}
+
+func TestScanSourcePatternsLongLine(t *testing.T) {
+	// Minified sources put everything on one line; only the text just
+	// before each literal is considered.
+	content := []byte(strings.Repeat(`log("x.y");`, 500) + `consumer.subscribe(Pattern.compile("orders\\..*"));`)
+
+	got := scanSourcePatterns(content, javaLiteralSyntax, SourceRegex)
+
+	want := []PatternReference{{Pattern: `orders\..*`, Line: 1, Column: 5537, Source: SourceRegex, Direction: DirectionConsume}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("scanSourcePatterns() = %#v, want %#v", got, want)
+	}
+}
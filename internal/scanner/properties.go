@@ -0,0 +1,415 @@
+package scanner
+
+import (
+	"strings"
+)
+
+// Sources for key/value configuration formats.
+const (
+	SourceProperties = "properties"
+	SourceHOCON      = "hocon"
+	SourceTOML       = "toml"
+	SourceINI        = "ini"
+)
+
+// propertyEntry is a flattened key/value pair from a configuration file.
+// Keys are dotted paths that include enclosing sections and objects.
+type propertyEntry struct {
+	Key   string
+	Value string
+	Line  int
+}
+
+// configDialect describes the comment and section syntax of a brace or
+// section structured format.
+type configDialect struct {
+	comments []string
+	// sections enables [table] and [[array]] headers.
+	sections bool
+}
+
+var (
+	tomlDialect  = configDialect{comments: []string{"#"}, sections: true}
+	hoconDialect = configDialect{comments: []string{"#", "//"}}
+)
+
+// scanKeyValueEntries reports topics for entries whose dotted key looks like
+// a topic key, as topicConfigLinePattern does for YAML/JSON lines.
+func scanKeyValueEntries(entries []propertyEntry, source string) []Reference {
+	refs := make([]Reference, 0)
+	for _, entry := range entries {
+		if !topicConfigKeyPattern.MatchString(entry.Key) || isTopicPatternKey(entry.Key) {
+			continue
+		}
+		direction := classifyDirection(entry.Key, "")
+		for _, topic := range extractTopicCandidates(entry.Value) {
+			refs = append(refs, Reference{Topic: topic, Line: entry.Line, Source: source, Direction: direction})
+		}
+	}
+	return refs
+}
+
+func scanPropertiesFile(content []byte) ([]Reference, error) {
+	return scanKeyValueEntries(parseProperties(content), SourceProperties), nil
+}
+
+func scanHOCONFile(content []byte) ([]Reference, error) {
+	return scanKeyValueEntries(parseStructuredConfig(content, hoconDialect), SourceHOCON), nil
+}
+
+func scanTOMLFile(content []byte) ([]Reference, error) {
+	return scanKeyValueEntries(parseStructuredConfig(content, tomlDialect), SourceTOML), nil
+}
+
+func scanINIFile(content []byte) ([]Reference, error) {
+	return scanKeyValueEntries(parseINI(content), SourceINI), nil
+}
+
+func splitConfigLines(content []byte) []string {
+	return strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+}
+
+// parseProperties parses Java .properties content: '#'/'!' comments, '=',
+// ':' or whitespace separators and backslash line continuations.
+func parseProperties(content []byte) []propertyEntry {
+	lines := splitConfigLines(content)
+	entries := make([]propertyEntry, 0)
+
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		logical := strings.TrimLeft(lines[i], " \t\f")
+		if logical == "" || strings.HasPrefix(logical, "#") || strings.HasPrefix(logical, "!") {
+			continue
+		}
+		for endsWithContinuation(logical) && i+1 < len(lines) {
+			i++
+			logical = logical[:len(logical)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		key, value := splitPropertyLine(logical)
+		if key == "" {
+			continue
+		}
+		entries = append(entries, propertyEntry{Key: key, Value: value, Line: lineNo})
+	}
+
+	return entries
+}
+
+func endsWithContinuation(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+func splitPropertyLine(line string) (string, string) {
+	var key strings.Builder
+	i := 0
+	for i < len(line) {
+		c := line[i]
+		if c == '\\' && i+1 < len(line) {
+			key.WriteByte(line[i+1])
+			i += 2
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			break
+		}
+		key.WriteByte(c)
+		i++
+	}
+
+	rest := strings.TrimLeft(line[i:], " \t\f")
+	if strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, ":") {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return key.String(), unescapePropertyValue(strings.TrimRight(rest, " \t\r"))
+}
+
+// unescapePropertyValue applies .properties escapes: \t, \n, \r, \f and a
+// backslash before any other character yields that character.
+func unescapePropertyValue(value string) string {
+	if !strings.Contains(value, "\\") {
+		return value
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c != '\\' || i+1 == len(value) {
+			out.WriteByte(c)
+			continue
+		}
+		i++
+		switch value[i] {
+		case 't':
+			out.WriteByte('\t')
+		case 'n':
+			out.WriteByte('\n')
+		case 'r':
+			out.WriteByte('\r')
+		case 'f':
+			out.WriteByte('\f')
+		default:
+			out.WriteByte(value[i])
+		}
+	}
+	return out.String()
+}
+
+// parseINI parses INI content: [section] headers, ';'/'#' comments, '=' or
+// ':' separators and indented continuation lines.
+func parseINI(content []byte) []propertyEntry {
+	entries := make([]propertyEntry, 0)
+	section := ""
+	lastKey := ""
+
+	for i, raw := range splitConfigLines(content) {
+		lineNo := i + 1
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" {
+			lastKey = ""
+			continue
+		}
+		if strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			lastKey = ""
+			continue
+		}
+		if lastKey != "" && (raw[0] == ' ' || raw[0] == '\t') {
+			entries = append(entries, propertyEntry{Key: lastKey, Value: trimmed, Line: lineNo})
+			continue
+		}
+
+		sep := strings.IndexAny(trimmed, "=:")
+		if sep <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(trimmed[:sep])
+		if section != "" {
+			key = section + "." + key
+		}
+		entries = append(entries, propertyEntry{Key: key, Value: strings.TrimSpace(trimmed[sep+1:]), Line: lineNo})
+		lastKey = key
+	}
+
+	return entries
+}
+
+// parseStructuredConfig parses HOCON and TOML content into dotted entries.
+// Objects ({ ... }), TOML table headers and multi-line arrays are tracked so
+// each array item keeps its own line number.
+func parseStructuredConfig(content []byte, dialect configDialect) []propertyEntry {
+	entries := make([]propertyEntry, 0)
+	section := ""
+	objects := make([]string, 0)
+	arrayKey := ""
+	arrayDepth := 0
+
+	qualify := func(key string) string {
+		parts := make([]string, 0, len(objects)+2)
+		if section != "" {
+			parts = append(parts, section)
+		}
+		parts = append(parts, objects...)
+		return strings.Join(append(parts, key), ".")
+	}
+
+	for i, raw := range splitConfigLines(content) {
+		lineNo := i + 1
+		line := strings.TrimSpace(stripConfigComment(raw, dialect.comments))
+		if line == "" {
+			continue
+		}
+
+		if arrayKey != "" {
+			arrayDepth += bracketDelta(line)
+			entries = append(entries, propertyEntry{Key: arrayKey, Value: line, Line: lineNo})
+			if arrayDepth <= 0 {
+				arrayKey = ""
+			}
+			continue
+		}
+
+		if dialect.sections && len(objects) == 0 && strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = normalizeConfigKey(strings.Trim(line, "[]"))
+			continue
+		}
+
+		for _, stmt := range splitConfigStatements(line) {
+			switch stmt.delim {
+			case '{':
+				objects = append(objects, normalizeConfigKey(strings.TrimRight(stmt.text, " \t=:")))
+				continue
+			case '}':
+				if len(objects) > 0 {
+					objects = objects[:len(objects)-1]
+				}
+				continue
+			}
+
+			key, value, ok := splitConfigAssignment(stmt.text)
+			if !ok {
+				continue
+			}
+			fullKey := qualify(key)
+			entries = append(entries, propertyEntry{Key: fullKey, Value: value, Line: lineNo})
+			if depth := bracketDelta(value); depth > 0 {
+				arrayKey = fullKey
+				arrayDepth = depth
+			}
+		}
+	}
+
+	return entries
+}
+
+type configStatement struct {
+	text  string
+	delim byte
+}
+
+// splitConfigStatements splits a line at '{', '}' and ',' outside quotes and
+// brackets. A '}' closes after the text that precedes it.
+func splitConfigStatements(line string) []configStatement {
+	out := make([]configStatement, 0, 1)
+	var quote byte
+	depth := 0
+	start := 0
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '$':
+			// ${substitution} is part of the value, not an object.
+			if i+1 < len(line) && line[i+1] == '{' {
+				if end := strings.IndexByte(line[i:], '}'); end > 0 {
+					i += end
+				}
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '{', '}', ',':
+			if depth > 0 {
+				continue
+			}
+			text := strings.TrimSpace(line[start:i])
+			if c == '}' {
+				if text != "" {
+					out = append(out, configStatement{text: text})
+				}
+				out = append(out, configStatement{delim: '}'})
+			} else {
+				out = append(out, configStatement{text: text, delim: c})
+			}
+			start = i + 1
+		}
+	}
+
+	if text := strings.TrimSpace(line[start:]); text != "" {
+		out = append(out, configStatement{text: text})
+	}
+	return out
+}
+
+// splitConfigAssignment splits "key = value" or "key: value" at the first
+// separator outside quotes.
+func splitConfigAssignment(text string) (string, string, bool) {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '=', ':':
+			key := normalizeConfigKey(strings.TrimSuffix(strings.TrimSpace(text[:i]), "+"))
+			if key == "" {
+				return "", "", false
+			}
+			return key, strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// normalizeConfigKey removes quoting and whitespace around dotted key parts.
+func normalizeConfigKey(key string) string {
+	parts := strings.Split(strings.TrimSpace(key), ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
+
+func bracketDelta(text string) int {
+	delta := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '[':
+			delta++
+		case ']':
+			delta--
+		}
+	}
+	return delta
+}
+
+// stripConfigComment cuts the line at the first comment marker outside
+// quotes.
+func stripConfigComment(line string, markers []string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		if c == '"' || c == '\'' {
+			quote = c
+			continue
+		}
+		for _, marker := range markers {
+			if strings.HasPrefix(line[i:], marker) {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProperties(t *testing.T) {
+	content := []byte(`# comment
+! also a comment
+kafka.topic=orders.created
+kafka.consumer.topics : payments.v1, \
+    refunds.v1
+
+spaced.key   audit.log
+escaped\:key=value
+`)
+
+	want := []propertyEntry{
+		{Key: "kafka.topic", Value: "orders.created", Line: 3},
+		{Key: "kafka.consumer.topics", Value: "payments.v1, refunds.v1", Line: 4},
+		{Key: "spaced.key", Value: "audit.log", Line: 7},
+		{Key: "escaped:key", Value: "value", Line: 8},
+	}
+	if got := parseProperties(content); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseProperties() = %#v, want %#v", got, want)
+	}
+}
+
+func TestScanKeyValueFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		scan func([]byte) ([]Reference, error)
+		in   string
+		want []Reference
+	}{
+		{
+			name: "properties",
+			scan: scanPropertiesFile,
+			in: `kafka.topic.orders=orders.created
+kafka.bootstrap=localhost:9092
+kafka.consumer.topics=payments.v1,refunds.v1
+`,
+			want: []Reference{
+				{Topic: "orders.created", Line: 1, Source: SourceProperties, Direction: DirectionUnknown},
+				{Topic: "payments.v1", Line: 3, Source: SourceProperties, Direction: DirectionConsume},
+				{Topic: "refunds.v1", Line: 3, Source: SourceProperties, Direction: DirectionConsume},
+			},
+		},
+		{
+			name: "hocon",
+			scan: scanHOCONFile,
+			in: `# service config
+kafka {
+  bootstrap = "localhost:9092"
+  producer { topic = "orders.created" }
+  consumer {
+    topics = [
+      "payments.v1",  // primary
+      "refunds.v1"
+    ]
+  }
+}
+kafka.topic.audit: ${?AUDIT_TOPIC}
+`,
+			want: []Reference{
+				{Topic: "orders.created", Line: 4, Source: SourceHOCON, Direction: DirectionProduce},
+				{Topic: "payments.v1", Line: 7, Source: SourceHOCON, Direction: DirectionConsume},
+				{Topic: "refunds.v1", Line: 8, Source: SourceHOCON, Direction: DirectionConsume},
+				{Topic: "${?AUDIT_TOPIC}", Line: 12, Source: SourceHOCON, Direction: DirectionUnknown},
+			},
+		},
+		{
+			name: "toml",
+			scan: scanTOMLFile,
+			in: `[kafka]
+brokers = ["localhost:9092"]
+
+[kafka.topics]
+orders = "orders.created" # main stream
+audit = { name = "audit.log", partitions = 3 }
+
+[[consumers]]
+topic = 'payments.v1'
+`,
+			want: []Reference{
+				{Topic: "orders.created", Line: 5, Source: SourceTOML, Direction: DirectionUnknown},
+				{Topic: "audit.log", Line: 6, Source: SourceTOML, Direction: DirectionUnknown},
+				{Topic: "payments.v1", Line: 9, Source: SourceTOML, Direction: DirectionConsume},
+			},
+		},
+		{
+			name: "ini",
+			scan: scanINIFile,
+			in: `; worker settings
+[producer]
+topic = orders.created
+[kafka]
+topics =
+    payments.v1
+    refunds.v1
+group = workers
+`,
+			want: []Reference{
+				{Topic: "orders.created", Line: 3, Source: SourceINI, Direction: DirectionProduce},
+				{Topic: "payments.v1", Line: 6, Source: SourceINI, Direction: DirectionUnknown},
+				{Topic: "refunds.v1", Line: 7, Source: SourceINI, Direction: DirectionUnknown},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.scan([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("scan error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("references = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,15 +4,18 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 )
 
@@ -24,8 +27,24 @@ type Scanner interface {
 // Result contains discovered topic references and scan metadata.
 type Result struct {
 	RepoPath     string                     `json:"repo_path"`
+	Revision     string                     `json:"revision,omitempty"`
 	FilesScanned int                        `json:"files_scanned"`
 	Topics       map[string]*TopicReference `json:"topics"`
+	Declarations []TopicDeclaration         `json:"declarations,omitempty"`
+	Patterns     []PatternReference         `json:"patterns,omitempty"`
+	Suppressions []Suppression              `json:"suppressions,omitempty"`
+	Suppressed   []SuppressedReference      `json:"suppressed,omitempty"`
+	// SkippedFiles and SkippedDirs count what the walk passed over, keyed
+	// by SkipReason*.
+	SkippedFiles map[string]int `json:"skipped_files,omitempty"`
+	SkippedDirs  map[string]int `json:"skipped_dirs,omitempty"`
+	// CachedFiles counts scanned files whose per-file results came from
+	// the scan cache.
+	CachedFiles int `json:"cached_files,omitempty"`
+	// Repos labels the repositories of a merged multi-repo result, and
+	// RepoRoots holds their absolute paths in the same order.
+	Repos     []string `json:"repos,omitempty"`
+	RepoRoots []string `json:"repo_roots,omitempty"`
 }
 
 // TopicReference aggregates all occurrences for a topic.
@@ -36,10 +55,32 @@ type TopicReference struct {
 
 // Reference is a single occurrence of a topic reference.
 type Reference struct {
-	Topic  string `json:"topic"`
-	File   string `json:"file"`
-	Line   int    `json:"line,omitempty"`
-	Source string `json:"source"`
+	Topic     string `json:"topic"`
+	File      string `json:"file"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	Source    string `json:"source"`
+	Direction string `json:"direction,omitempty"`
+	// LiteralColumn is the column of a quoted literal whose source text is
+	// exactly Topic, or 0 when the topic is not spelled out verbatim
+	// (identifiers, concatenations, placeholders). Only such spans can be
+	// renamed in place.
+	LiteralColumn int `json:"literal_column,omitempty"`
+	// Pattern is set when the reference comes from a subscription pattern
+	// that matched this topic in the cluster.
+	Pattern string `json:"pattern,omitempty"`
+	// Template is the templated name, e.g. "{env}.orders.v1", the topic was
+	// built from.
+	Template string `json:"template,omitempty"`
+	// Resolution records how a ${VAR} placeholder was resolved, outermost
+	// variable first.
+	Resolution []VariableBinding `json:"resolution,omitempty"`
+	// Confidence scores how likely the reference is a real topic, from 0
+	// to 1.
+	Confidence float64 `json:"confidence"`
+	// Repo labels the repository in a multi-repo scan; File is relative
+	// to it.
+	Repo string `json:"repo,omitempty"`
 }
 
 const (
@@ -55,25 +96,44 @@ const (
 	scanConfig
 	scanEnv
 	scanSource
+	scanTerraform
+	scanLanguage
+	scanGo
+	scanProperties
+	scanHOCON
+	scanTOML
+	scanINI
+	scanSQL
+	scanArchive
 )
 
+// topicConfigKeyExpr matches configuration keys that name a topic.
+const topicConfigKeyExpr = `[A-Za-z0-9_.-]*topic[s]?[A-Za-z0-9_.-]*`
+
 var (
-	topicConfigLinePattern = regexp.MustCompile(`(?i)^\s*(?:-\s*)?["']?([A-Za-z0-9_.-]*topic[s]?[A-Za-z0-9_.-]*)["']?\s*[:=]\s*(.*?)\s*,?\s*$`)
+	topicConfigLinePattern = regexp.MustCompile(`(?i)^\s*(?:-\s*)?["']?(` + topicConfigKeyExpr + `)["']?\s*[:=]\s*(.*?)\s*,?\s*$`)
+	topicConfigKeyPattern  = regexp.MustCompile(`(?i)^` + topicConfigKeyExpr + `$`)
 	envLinePattern         = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
 	quotedTokenPattern     = regexp.MustCompile("[\"'`]([A-Za-z0-9._-]{3,249})[\"'`]")
 	plainTokenPattern      = regexp.MustCompile(`[A-Za-z0-9._-]{3,249}`)
+	configKeyPattern       = regexp.MustCompile(`^\s*["']?([A-Za-z0-9_.-]+)["']?\s*:`)
 )
 
 // RepoScanner scans source repositories for topic references.
 type RepoScanner struct {
-	maxFileSize int64
-	skipDirs    map[string]struct{}
+	maxFileSize  int64
+	skipDirs     map[string]struct{}
+	include      []*regexp.Regexp
+	exclude      []*regexp.Regexp
+	workers      int
+	cacheDir     string
+	scanArchives bool
 }
 
 // NewRepoScanner returns the default repository scanner.
 func NewRepoScanner() *RepoScanner {
 	return &RepoScanner{
-		maxFileSize: 2 * 1024 * 1024,
+		maxFileSize: DefaultMaxFileSize,
 		skipDirs: map[string]struct{}{
 			".git":         {},
 			".idea":        {},
@@ -89,101 +149,179 @@ func NewRepoScanner() *RepoScanner {
 	}
 }
 
+// NewRepoScannerWithConfig returns a scanner that applies cfg on top of the
+// defaults.
+func NewRepoScannerWithConfig(cfg Config) (*RepoScanner, error) {
+	s := NewRepoScanner()
+	if cfg.MaxFileSize < 0 {
+		return nil, errors.New("max file size must not be negative")
+	}
+	if cfg.MaxFileSize > 0 {
+		s.maxFileSize = cfg.MaxFileSize
+	}
+	if cfg.Workers < 0 {
+		return nil, errors.New("workers must not be negative")
+	}
+	s.workers = cfg.Workers
+	s.cacheDir = strings.TrimSpace(cfg.CacheDir)
+	s.scanArchives = cfg.ScanArchives
+
+	var err error
+	if s.include, err = compileGlobs(cfg.Include); err != nil {
+		return nil, fmt.Errorf("include: %w", err)
+	}
+	if s.exclude, err = compileGlobs(cfg.Exclude); err != nil {
+		return nil, fmt.Errorf("exclude: %w", err)
+	}
+	return s, nil
+}
+
+// readFunc reads a file by absolute path. Working-tree scans use
+// os.ReadFile; revision scans read from the git object database.
+type readFunc func(path string) ([]byte, error)
+
 // Scan walks the repository and extracts topic references from supported files.
 func (s *RepoScanner) Scan(ctx context.Context, repoPath string) (*Result, error) {
+	absRepoPath, err := resolveRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newResult(absRepoPath)
+	files, err := s.walk(ctx, absRepoPath, result)
+	if err != nil {
+		return nil, err
+	}
+	return s.scanWalked(ctx, result, files, os.ReadFile)
+}
+
+func resolveRepoPath(repoPath string) (string, error) {
 	repoPath = strings.TrimSpace(repoPath)
 	if repoPath == "" {
-		return nil, errors.New("repo path is required")
+		return "", errors.New("repo path is required")
 	}
 
 	absRepoPath, err := filepath.Abs(repoPath)
 	if err != nil {
-		return nil, fmt.Errorf("resolve repo path: %w", err)
+		return "", fmt.Errorf("resolve repo path: %w", err)
 	}
 
 	info, err := os.Stat(absRepoPath)
 	if err != nil {
-		return nil, fmt.Errorf("repo path %q: %w", repoPath, err)
+		return "", fmt.Errorf("repo path %q: %w", repoPath, err)
 	}
 	if !info.IsDir() {
-		return nil, fmt.Errorf("repo path %q is not a directory", repoPath)
+		return "", fmt.Errorf("repo path %q is not a directory", repoPath)
 	}
+	return absRepoPath, nil
+}
 
-	result := &Result{
-		RepoPath: absRepoPath,
-		Topics:   make(map[string]*TopicReference),
+func newResult(absRepoPath string) *Result {
+	return &Result{
+		RepoPath:     absRepoPath,
+		Topics:       make(map[string]*TopicReference),
+		SkippedFiles: make(map[string]int),
+		SkippedDirs:  make(map[string]int),
 	}
-	dedupe := make(map[string]map[string]struct{})
+}
 
-	err = filepath.WalkDir(absRepoPath, func(path string, d fs.DirEntry, walkErr error) error {
-		if walkErr != nil {
-			return walkErr
-		}
-		if err := ctx.Err(); err != nil {
-			return err
-		}
+// scanWalked parses the walked files and runs the cross-file passes.
+func (s *RepoScanner) scanWalked(ctx context.Context, result *Result, files []walkedFile, read readFunc) (*Result, error) {
+	absRepoPath := result.RepoPath
+	cache := loadScanCache(s.cacheDir, absRepoPath, s.maxFileSize, s.scanArchives)
+	scans, err := s.scanFiles(ctx, files, cache, read)
+	if err != nil {
+		return nil, err
+	}
 
-		if d.IsDir() {
-			if s.shouldSkipDir(d.Name()) {
-				return filepath.SkipDir
+	dedupe := make(map[string]map[string]struct{})
+	goFiles := make([]goSourceFile, 0)
+	springPropertyFiles := make([]springFile, 0)
+	springSourceFiles := make([]springFile, 0)
+	goInputs, springInputs := sha256.New(), sha256.New()
+	vars := make(variableSet)
+
+	// Per-file results are merged in walk order so output does not depend
+	// on worker scheduling.
+	for _, scan := range scans {
+		relPath := scan.relPath
+		// Archive entries are attributed to archive!/path/inside.
+		fileOf := func(inner string) string {
+			if scan.mode == scanArchive {
+				return relPath + "!/" + inner
 			}
-			return nil
+			return relPath
 		}
-		if !d.Type().IsRegular() {
-			return nil
+		result.FilesScanned++
+		if scan.cached {
+			result.CachedFiles++
 		}
 
-		mode := detectScanMode(path)
-		if mode == scanNone {
-			return nil
+		for _, suppression := range scan.result.Suppressions {
+			suppression.File = fileOf(suppression.File)
+			result.Suppressions = append(result.Suppressions, suppression)
 		}
-
-		fileInfo, err := d.Info()
-		if err != nil {
-			return err
+		for _, binding := range scan.result.Variables {
+			vars.bind(binding.Name, binding.Value, relPath, binding.Line)
 		}
-		if fileInfo.Size() > s.maxFileSize {
-			return nil
+		if scan.springProperty {
+			springPropertyFiles = append(springPropertyFiles, springFile{path: scan.path, relPath: relPath})
+			fmt.Fprintf(springInputs, "property\x00%s\x00%s\n", relPath, scan.result.Hash)
+		} else if scan.springSource {
+			springSourceFiles = append(springSourceFiles, springFile{path: scan.path, relPath: relPath})
+			fmt.Fprintf(springInputs, "source\x00%s\x00%s\n", relPath, scan.result.Hash)
 		}
-
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return err
+		if scan.mode == scanGo {
+			goFiles = append(goFiles, goSourceFile{path: scan.path, relPath: relPath})
+			fmt.Fprintf(goInputs, "%s\x00%s\n", relPath, scan.result.Hash)
 		}
-		result.FilesScanned++
 
-		relPath, err := filepath.Rel(absRepoPath, path)
-		if err != nil {
-			relPath = path
-		}
-		relPath = filepath.ToSlash(relPath)
-
-		var refs []Reference
-		switch mode {
-		case scanConfig:
-			refs, err = scanConfigFile(content)
-		case scanEnv:
-			refs, err = scanEnvFile(content)
-		case scanSource:
-			refs, err = scanSourceFile(content)
-		default:
-			return nil
+		for _, ref := range scan.result.References {
+			ref.File = fileOf(ref.File)
+			addReference(result, dedupe, ref)
 		}
-		if err != nil {
-			return fmt.Errorf("scan %s: %w", relPath, err)
+		for _, decl := range scan.result.Declarations {
+			decl.File = fileOf(decl.File)
+			result.Declarations = append(result.Declarations, decl)
 		}
+		for _, pattern := range scan.result.Patterns {
+			pattern.File = fileOf(pattern.File)
+			result.Patterns = append(result.Patterns, pattern)
+		}
+	}
+
+	// Go files are resolved per package once every file has been read.
+	goPass, err := runGoPass(cache, hex.EncodeToString(goInputs.Sum(nil)), absRepoPath, goFiles, read)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range goFiles {
+		for _, ref := range goPass.References[file.relPath] {
+			ref.File = file.relPath
+			addReference(result, dedupe, ref)
+		}
+	}
 
+	springPass, err := runSpringPass(cache, hex.EncodeToString(springInputs.Sum(nil)), springPropertyFiles, springSourceFiles, read)
+	if err != nil {
+		return nil, err
+	}
+	for relPath, refs := range springPass.References {
 		for _, ref := range refs {
 			ref.File = relPath
 			addReference(result, dedupe, ref)
 		}
+	}
+	result.Patterns = mergePrecisePatterns(result.Patterns, springPass.Patterns)
 
-		return nil
-	})
-	if err != nil {
+	if err := cache.save(scans); err != nil {
 		return nil, err
 	}
 
+	resolvePlaceholderReferences(result, dedupe, vars)
+	dropShadowedReferences(result)
+	applySuppressions(result)
+
 	for _, topicRef := range result.Topics {
 		sort.Slice(topicRef.Occurrences, func(i, j int) bool {
 			left := topicRef.Occurrences[i]
@@ -201,9 +339,231 @@ func (s *RepoScanner) Scan(ctx context.Context, repoPath string) (*Result, error
 	return result, nil
 }
 
-func (s *RepoScanner) shouldSkipDir(name string) bool {
-	_, skip := s.skipDirs[strings.ToLower(name)]
-	return skip
+// runGoPass runs scanGoFiles, or returns its cached result when no Go file
+// and no go.mod file it read has changed.
+func runGoPass(cache *scanCache, key, absRepoPath string, files []goSourceFile, read readFunc) (*passResult, error) {
+	if pass, ok := cache.lookupPass("go", key, read); ok {
+		return pass, nil
+	}
+
+	for idx := range files {
+		content, err := read(files[idx].path)
+		if err != nil {
+			return nil, err
+		}
+		files[idx].content = content
+	}
+	modules := make(map[string]string)
+	readModule := func(path string) ([]byte, error) {
+		content, err := read(path)
+		modules[path] = ""
+		if err == nil {
+			modules[path] = contentHash(content)
+		}
+		return content, err
+	}
+	pass := &passResult{Key: key, Modules: modules, References: scanGoFiles(absRepoPath, files, readModule)}
+	cache.storePass("go", pass)
+	return pass, nil
+}
+
+// runSpringPass runs scanSpringFiles, or returns its cached result when no
+// Spring properties or source file has changed.
+func runSpringPass(cache *scanCache, key string, propertyFiles, sourceFiles []springFile, read readFunc) (*passResult, error) {
+	if pass, ok := cache.lookupPass("spring", key, read); ok {
+		return pass, nil
+	}
+
+	for _, files := range [][]springFile{propertyFiles, sourceFiles} {
+		for idx := range files {
+			content, err := read(files[idx].path)
+			if err != nil {
+				return nil, err
+			}
+			files[idx].content = content
+		}
+	}
+	refs, patterns := scanSpringFiles(propertyFiles, sourceFiles)
+	pass := &passResult{Key: key, References: refs, Patterns: patterns}
+	cache.storePass("spring", pass)
+	return pass, nil
+}
+
+// scanFiles reads and parses files on a pool of workers. The returned
+// slice is in the same order as files.
+func (s *RepoScanner) scanFiles(ctx context.Context, files []walkedFile, cache *scanCache, read readFunc) ([]scannedFile, error) {
+	workers := s.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	scans := make([]scannedFile, len(files))
+	errs := make([]error, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, max(len(files), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				scans[idx], errs[idx] = s.scanFile(files[idx], cache, read)
+			}
+		}()
+	}
+
+feed:
+	for idx := range files {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- idx:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Report the first failure in walk order, as a sequential scan would.
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return scans, nil
+}
+
+// scanFile reads one file and returns its per-file scan, from the cache
+// when the content hash matches.
+func (s *RepoScanner) scanFile(file walkedFile, cache *scanCache, read readFunc) (scannedFile, error) {
+	content, err := read(file.path)
+	if err != nil {
+		return scannedFile{}, err
+	}
+
+	scanned := scannedFile{walkedFile: file, springProperty: isSpringPropertyFile(file.path)}
+	scanned.springSource = !scanned.springProperty && isSpringSourceFile(file.path, content)
+
+	hash := contentHash(content)
+	if cached, ok := cache.lookup(file.relPath, hash); ok {
+		scanned.result = cached
+		scanned.cached = true
+		return scanned, nil
+	}
+
+	if file.mode == scanArchive {
+		scanned.result = parseArchive(content, s.maxFileSize, newArchiveBudget(), 1)
+	} else {
+		scanned.result, err = parseFile(file, content)
+	}
+	if err != nil {
+		return scannedFile{}, fmt.Errorf("scan %s: %w", file.relPath, err)
+	}
+	scanned.result.Hash = hash
+	return scanned, nil
+}
+
+// parseFile extracts everything a file contributes on its own: references,
+// declarations, patterns, suppressions and variable definitions.
+func parseFile(file walkedFile, content []byte) (fileResult, error) {
+	result := fileResult{Suppressions: parseSuppressions(content)}
+
+	vars := make(variableSet)
+	if file.mode == scanEnv {
+		collectEnvVariables(vars, file.relPath, content)
+	} else if isYAMLFile(file.path) {
+		collectYAMLVariables(vars, file.relPath, content)
+	}
+	result.Variables = vars.bindings()
+
+	var (
+		patterns []PatternReference
+		err      error
+	)
+	switch file.mode {
+	case scanGo:
+		// AST references are added once every Go file has been read.
+		result.References, err = scanSourceFile(content)
+		return result, err
+	case scanConfig:
+		result.References, err = scanConfigFile(content)
+		if err == nil && isYAMLFile(file.path) {
+			strimziRefs, strimziDecls := scanStrimziTopics(content)
+			result.References = append(result.References, strimziRefs...)
+			result.Declarations = append(result.Declarations, strimziDecls...)
+		}
+		if err == nil {
+			asyncRefs, asyncDecls := scanAsyncAPIDocument(file.path, content)
+			result.References = append(result.References, asyncRefs...)
+			result.Declarations = append(result.Declarations, asyncDecls...)
+		}
+	case scanEnv:
+		result.References, err = scanEnvFile(content)
+	case scanSource:
+		result.References, err = scanSourceFile(content)
+	case scanTerraform:
+		result.References, result.Declarations, err = scanTerraformFile(content)
+	case scanProperties:
+		result.References, err = scanPropertiesFile(content)
+	case scanHOCON:
+		result.References, err = scanHOCONFile(content)
+	case scanTOML:
+		result.References, err = scanTOMLFile(content)
+	case scanINI:
+		result.References, err = scanINIFile(content)
+	case scanSQL:
+		result.References, patterns = scanSQLFile(content)
+	case scanLanguage:
+		result.References, err = scanLanguageFile(content, sourceLanguages[strings.ToLower(filepath.Ext(file.path))])
+	}
+	if err != nil {
+		return fileResult{}, err
+	}
+
+	result.Patterns = append(scanFilePatterns(file.mode, file.path, content), patterns...)
+	if file.mode == scanConfig || file.mode == scanProperties {
+		connectRefs, connectPatterns, lines := scanConnectorConfigs(file.path, content)
+		dropConnectorLines(&result, lines)
+		result.References = append(result.References, connectRefs...)
+		result.Patterns = append(result.Patterns, connectPatterns...)
+	}
+	return result, nil
+}
+
+func (s *RepoScanner) dirSkipReason(name, relPath string, rules ignoreRules) string {
+	if _, skip := s.skipDirs[strings.ToLower(name)]; skip {
+		return SkipReasonDefaultDir
+	}
+	if rules.ignored(relPath, true) {
+		return SkipReasonIgnoreFile
+	}
+	if matchesAnyGlob(s.exclude, relPath) {
+		return SkipReasonExcluded
+	}
+	return ""
+}
+
+func (s *RepoScanner) fileSkipReason(relPath string, mode scanMode, rules ignoreRules) string {
+	switch {
+	case rules.ignored(relPath, false):
+		return SkipReasonIgnoreFile
+	case matchesAnyGlob(s.exclude, relPath):
+		return SkipReasonExcluded
+	case len(s.include) > 0 && !matchesAnyGlob(s.include, relPath):
+		return SkipReasonNotIncluded
+	case mode == scanNone, mode == scanArchive && !s.scanArchives:
+		return SkipReasonUnsupported
+	}
+	return ""
+}
+
+// sizeLimit is the largest file of the given mode the walk selects.
+func (s *RepoScanner) sizeLimit(mode scanMode) int64 {
+	if mode == scanArchive {
+		return max(s.maxFileSize, DefaultMaxArchiveSize)
+	}
+	return s.maxFileSize
 }
 
 func detectScanMode(path string) scanMode {
@@ -215,19 +575,50 @@ func detectScanMode(path string) scanMode {
 		return scanEnv
 	case ext == ".yaml" || ext == ".yml" || ext == ".json":
 		return scanConfig
-	case ext == ".go" || ext == ".py" || ext == ".java":
+	case ext == ".go":
+		return scanGo
+	case ext == ".py" || ext == ".java":
 		return scanSource
+	case ext == ".properties":
+		return scanProperties
+	case ext == ".conf" || ext == ".hocon":
+		return scanHOCON
+	case ext == ".toml":
+		return scanTOML
+	case ext == ".ini":
+		return scanINI
+	case ext == ".tf":
+		return scanTerraform
+	case ext == ".sql" || ext == ".ksql":
+		return scanSQL
+	case isArchiveFile(path):
+		return scanArchive
+	case sourceLanguages[ext] != nil:
+		return scanLanguage
 	default:
 		return scanNone
 	}
 }
 
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
 func scanConfigFile(content []byte) ([]Reference, error) {
 	lines := bufio.NewScanner(bytes.NewReader(content))
 	lines.Buffer(make([]byte, 0, 64*1024), 2*1024*1024)
 
 	refs := make([]Reference, 0)
 	pendingTopicListIndent := -1
+	pendingDirection := DirectionUnknown
+	// keyPath tracks enclosing mapping keys so that e.g. consumer.topics
+	// classifies as a consume reference.
+	type configKey struct {
+		indent int
+		key    string
+	}
+	keyPath := make([]configKey, 0)
 
 	for lineNo := 1; lines.Scan(); lineNo++ {
 		line := lines.Text()
@@ -241,7 +632,7 @@ func scanConfigFile(content []byte) ([]Reference, error) {
 			if indent > pendingTopicListIndent && strings.HasPrefix(strings.TrimSpace(line), "-") {
 				value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "-"))
 				for _, topic := range extractTopicCandidates(value) {
-					refs = append(refs, Reference{Topic: topic, Line: lineNo, Source: SourceYAMLJSON})
+					refs = append(refs, Reference{Topic: topic, Line: lineNo, Source: SourceYAMLJSON, Direction: pendingDirection})
 				}
 				continue
 			}
@@ -250,19 +641,33 @@ func scanConfigFile(content []byte) ([]Reference, error) {
 			}
 		}
 
+		parents := make([]string, 0, len(keyPath)+1)
+		if keyMatch := configKeyPattern.FindStringSubmatch(line); len(keyMatch) == 2 && !strings.HasPrefix(trimmed, "-") {
+			indent := leadingIndent(line)
+			for len(keyPath) > 0 && keyPath[len(keyPath)-1].indent >= indent {
+				keyPath = keyPath[:len(keyPath)-1]
+			}
+			for _, parent := range keyPath {
+				parents = append(parents, parent.key)
+			}
+			keyPath = append(keyPath, configKey{indent: indent, key: keyMatch[1]})
+		}
+
 		match := topicConfigLinePattern.FindStringSubmatch(line)
-		if len(match) != 3 {
+		if len(match) != 3 || isTopicPatternKey(match[1]) {
 			continue
 		}
 
+		direction := classifyDirection(strings.Join(append(parents, match[1]), "."), "")
 		value := strings.TrimSpace(match[2])
 		if value == "" || value == "|" || value == ">" {
 			pendingTopicListIndent = leadingIndent(line)
+			pendingDirection = direction
 			continue
 		}
 
 		for _, topic := range extractTopicCandidates(value) {
-			refs = append(refs, Reference{Topic: topic, Line: lineNo, Source: SourceYAMLJSON})
+			refs = append(refs, Reference{Topic: topic, Line: lineNo, Source: SourceYAMLJSON, Direction: direction})
 		}
 	}
 
@@ -291,13 +696,14 @@ func scanEnvFile(content []byte) ([]Reference, error) {
 		}
 
 		key := strings.ToUpper(match[1])
-		if !strings.Contains(key, "TOPIC") {
+		if !strings.Contains(key, "TOPIC") || isTopicPatternKey(key) {
 			continue
 		}
 
+		direction := classifyDirection(key, "")
 		value := strings.TrimSpace(stripInlineComment(match[2]))
 		for _, topic := range extractTopicCandidates(value) {
-			refs = append(refs, Reference{Topic: topic, Line: lineNo, Source: SourceEnv})
+			refs = append(refs, Reference{Topic: topic, Line: lineNo, Source: SourceEnv, Direction: direction})
 		}
 	}
 
@@ -326,16 +732,27 @@ func scanSourceFile(content []byte) ([]Reference, error) {
 			continue
 		}
 
-		matches := quotedTokenPattern.FindAllStringSubmatch(line, -1)
+		logContext := hasLogContext(line)
+		matches := quotedTokenPattern.FindAllStringSubmatchIndex(line, -1)
 		for _, m := range matches {
-			if len(m) != 2 {
+			if len(m) != 4 {
 				continue
 			}
-			topic := m[1]
+			topic := line[m[2]:m[3]]
 			if !isLikelyTopic(topic, line) {
 				continue
 			}
-			refs = append(refs, Reference{Topic: topic, Line: lineNo, Source: SourceRegex})
+			direction := classifyDirection(line[:m[0]], line[m[1]:])
+			ref := Reference{Topic: topic, Line: lineNo, Source: SourceRegex, Direction: direction}
+			ref.Confidence = referenceConfidence(ref, logContext)
+			refs = append(refs, ref)
+		}
+
+		for _, template := range sourceFormatTemplates(line) {
+			direction := classifyDirection(line[:template.column-1], line[template.column-1:])
+			ref := Reference{Topic: template.template, Line: lineNo, Column: template.column, Source: SourceRegex, Direction: direction, Template: template.template}
+			ref.Confidence = referenceConfidence(ref, logContext)
+			refs = append(refs, ref)
 		}
 	}
 
@@ -346,10 +763,68 @@ func scanSourceFile(content []byte) ([]Reference, error) {
 	return refs, nil
 }
 
+// preciseSources resolve references from syntax rather than line
+// heuristics; they shadow heuristic hits for the same topic on the same line.
+var preciseSources = map[string]struct{}{
+	SourceGoAST:    {},
+	SourceSpring:   {},
+	SourceAsyncAPI: {},
+}
+
+// mergePrecisePatterns adds patterns found by a precise source. A heuristic
+// pattern with the same text on the same line is replaced in place.
+func mergePrecisePatterns(patterns, precise []PatternReference) []PatternReference {
+	if len(precise) == 0 {
+		return patterns
+	}
+	byLine := make(map[string]int, len(patterns))
+	for i, pattern := range patterns {
+		byLine[fmt.Sprintf("%s:%d:%s", pattern.File, pattern.Line, pattern.Pattern)] = i
+	}
+	for _, pattern := range precise {
+		if i, ok := byLine[fmt.Sprintf("%s:%d:%s", pattern.File, pattern.Line, pattern.Pattern)]; ok {
+			patterns[i] = pattern
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+func dropShadowedReferences(result *Result) {
+	for _, topicRef := range result.Topics {
+		precise := make(map[string]struct{})
+		for _, ref := range topicRef.Occurrences {
+			if _, ok := preciseSources[ref.Source]; ok {
+				precise[fmt.Sprintf("%s:%d", ref.File, ref.Line)] = struct{}{}
+			}
+		}
+		if len(precise) == 0 {
+			continue
+		}
+
+		kept := topicRef.Occurrences[:0]
+		for _, ref := range topicRef.Occurrences {
+			_, isPrecise := preciseSources[ref.Source]
+			if _, shadowed := precise[fmt.Sprintf("%s:%d", ref.File, ref.Line)]; shadowed && !isPrecise {
+				continue
+			}
+			kept = append(kept, ref)
+		}
+		topicRef.Occurrences = kept
+	}
+}
+
 func addReference(result *Result, dedupe map[string]map[string]struct{}, ref Reference) {
 	if ref.Topic == "" {
 		return
 	}
+	if ref.Direction == "" {
+		ref.Direction = DirectionUnknown
+	}
+	if ref.Confidence == 0 {
+		ref.Confidence = referenceConfidence(ref, false)
+	}
 
 	topicRef, exists := result.Topics[ref.Topic]
 	if !exists {
@@ -376,18 +851,24 @@ func extractTopicCandidates(value string) []string {
 		return nil
 	}
 
-	// Skip simple env interpolation placeholders like ${KAFKA_TOPIC}.
-	if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
-		return nil
-	}
+	// Placeholders like ${KAFKA_TOPIC} or orders.${ENV} are kept whole as
+	// templates for resolvePlaceholderReferences.
+	templates := topicTemplatePattern.FindAllString(value, -1)
+	value = topicTemplatePattern.ReplaceAllString(value, " ")
 
 	matches := plainTokenPattern.FindAllString(value, -1)
-	if len(matches) == 0 {
+	if len(matches) == 0 && len(templates) == 0 {
 		return nil
 	}
 
-	out := make([]string, 0, len(matches))
+	out := make([]string, 0, len(matches)+len(templates))
 	seen := make(map[string]struct{})
+	for _, template := range templates {
+		if _, ok := seen[template]; !ok {
+			seen[template] = struct{}{}
+			out = append(out, template)
+		}
+	}
 	for _, match := range matches {
 		if !isLikelyTopic(match, value) {
 			continue
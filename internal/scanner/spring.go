@@ -0,0 +1,295 @@
+package scanner
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SourceSpring marks references found through Spring Kafka annotations,
+// KafkaTemplate/TopicBuilder calls and Spring Cloud Stream bindings.
+const SourceSpring = "spring"
+
+var (
+	springPropertyFilePattern = regexp.MustCompile(`^(?:application|bootstrap)(?:-[A-Za-z0-9_.-]+)?\.(?:ya?ml|properties)$`)
+	springSourceHintPattern   = regexp.MustCompile(`KafkaListener|KafkaTemplate|TopicBuilder|NewTopic|@SendTo`)
+	springBindingPattern      = regexp.MustCompile(`^spring\.cloud\.stream\.bindings\.([^.]+)\.destination$`)
+	springPlaceholderPattern  = regexp.MustCompile(`\$\{([^}:]+)(?::([^}]*))?\}`)
+
+	// Patterns below run on source where every string literal has been
+	// replaced by \x00<index>\x00, so quotes and commas inside literals
+	// cannot confuse them.
+	springMaskedLiteralPattern = regexp.MustCompile("\x00(\\d+)\x00")
+	springListenerPattern      = regexp.MustCompile(`@KafkaListener\s*\(`)
+	springTopicsAttrPattern    = regexp.MustCompile("\\btopics?\\s*=\\s*(\\{[^}]*\\}|\\[[^\\]]*\\]|arrayOf\\s*\\([^)]*\\)|\x00\\d+\x00)")
+	springTopicPatternAttr     = regexp.MustCompile("\\btopicPattern\\s*=\\s*\x00(\\d+)\x00")
+	springCallPatterns         = []struct {
+		pattern   *regexp.Regexp
+		direction string
+	}{
+		{regexp.MustCompile("(?i)\\w*template\\w*\\s*\\.\\s*send\\s*\\(\\s*\x00(\\d+)\x00"), DirectionProduce},
+		{regexp.MustCompile("@SendTo\\s*\\(\\s*(?:value\\s*=\\s*)?\x00(\\d+)\x00"), DirectionProduce},
+		{regexp.MustCompile("TopicBuilder\\s*\\.\\s*name\\s*\\(\\s*\x00(\\d+)\x00"), DirectionAdmin},
+		{regexp.MustCompile("\\bNewTopic\\s*\\(\\s*\x00(\\d+)\x00"), DirectionAdmin},
+	}
+)
+
+var javaLiteralSyntax = literalSyntax{
+	lineComments:  []string{"//"},
+	blockComments: true,
+	tripleQuoted:  true,
+}
+
+// springFile is a Spring property file or Spring-flavoured source file
+// collected during the walk.
+type springFile struct {
+	path    string
+	relPath string
+	content []byte
+}
+
+// springProperties holds Spring configuration keys for placeholder
+// resolution; base files take precedence over profile-specific ones.
+type springProperties map[string]string
+
+func isSpringPropertyFile(path string) bool {
+	return springPropertyFilePattern.MatchString(strings.ToLower(filepath.Base(path)))
+}
+
+func isSpringSourceFile(path string, content []byte) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".java", ".kt", ".kts", ".groovy":
+		return springSourceHintPattern.Match(content)
+	default:
+		return false
+	}
+}
+
+// scanSpringFiles loads Spring properties, then extracts destinations from
+// property files and topics from annotated sources, resolving ${...}
+// placeholders. References are keyed by repository-relative path;
+// @KafkaListener(topicPattern = ...) subscriptions are returned as patterns
+// with File set, in source file order.
+func scanSpringFiles(propertyFiles, sourceFiles []springFile) (map[string][]Reference, []PatternReference) {
+	sort.SliceStable(propertyFiles, func(i, j int) bool {
+		return springProfileRank(propertyFiles[i].relPath) < springProfileRank(propertyFiles[j].relPath)
+	})
+
+	props := make(springProperties)
+	entriesByFile := make(map[string][]propertyEntry, len(propertyFiles))
+	for _, file := range propertyFiles {
+		entries := springPropertyEntries(file)
+		entriesByFile[file.relPath] = entries
+		for _, entry := range entries {
+			if _, exists := props[entry.Key]; !exists {
+				props[entry.Key] = entry.Value
+			}
+		}
+	}
+
+	out := make(map[string][]Reference)
+	for _, file := range propertyFiles {
+		for _, entry := range entriesByFile[file.relPath] {
+			direction := ""
+			if match := springBindingPattern.FindStringSubmatch(entry.Key); len(match) == 2 {
+				direction = springBindingDirection(match[1])
+			} else if entry.Key == "spring.kafka.template.default-topic" {
+				direction = DirectionProduce
+			} else {
+				continue
+			}
+			for _, topic := range props.resolveTopics(entry.Value) {
+				out[file.relPath] = append(out[file.relPath], Reference{Topic: topic, Line: entry.Line, Source: SourceSpring, Direction: direction})
+			}
+		}
+	}
+
+	var patterns []PatternReference
+	for _, file := range sourceFiles {
+		refs, filePatterns := scanSpringSource(file, props)
+		out[file.relPath] = append(out[file.relPath], refs...)
+		for _, pattern := range filePatterns {
+			pattern.File = file.relPath
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return out, patterns
+}
+
+func springPropertyEntries(file springFile) []propertyEntry {
+	if strings.HasSuffix(strings.ToLower(file.relPath), ".properties") {
+		return parseProperties(file.content)
+	}
+
+	entries := make([]propertyEntry, 0)
+	for _, doc := range parseYAMLDocuments(file.content) {
+		doc.flatten("", &entries)
+	}
+	return entries
+}
+
+func springProfileRank(relPath string) int {
+	base := strings.ToLower(filepath.Base(relPath))
+	if strings.Contains(strings.TrimSuffix(base, filepath.Ext(base)), "-") {
+		return 1
+	}
+	return 0
+}
+
+func springBindingDirection(binding string) string {
+	lower := strings.ToLower(binding)
+	switch {
+	case strings.Contains(lower, "-in-") || strings.HasSuffix(lower, "input") || strings.HasSuffix(lower, "-in"):
+		return DirectionConsume
+	case strings.Contains(lower, "-out-") || strings.HasSuffix(lower, "output") || strings.HasSuffix(lower, "-out"):
+		return DirectionProduce
+	default:
+		return DirectionUnknown
+	}
+}
+
+func scanSpringSource(file springFile, props springProperties) ([]Reference, []PatternReference) {
+	syntax := javaLiteralSyntax
+	if lang := sourceLanguages[strings.ToLower(filepath.Ext(file.relPath))]; lang != nil {
+		syntax = lang.syntax
+	}
+
+	literals := extractStringLiterals(file.content, syntax)
+	masked := maskLiterals(file.content, literals)
+	refs := make([]Reference, 0)
+
+	emit := func(index int, direction string) {
+		if index < 0 || index >= len(literals) {
+			return
+		}
+		literal := literals[index]
+		for _, topic := range props.resolveTopics(literal.Value) {
+			ref := Reference{Topic: topic, Line: literal.Line, Column: literal.Column, Source: SourceSpring, Direction: direction}
+			if topic == literal.Value {
+				ref.LiteralColumn = literal.verbatimColumn(file.content, topic)
+			}
+			refs = append(refs, ref)
+		}
+	}
+
+	patterns := make([]PatternReference, 0)
+	for _, loc := range springListenerPattern.FindAllStringIndex(masked, -1) {
+		args := masked[loc[1]:matchingParen(masked, loc[1]-1)]
+		for _, attr := range springTopicsAttrPattern.FindAllStringSubmatch(args, -1) {
+			for _, index := range maskedLiteralIndexes(attr[1]) {
+				emit(index, DirectionConsume)
+			}
+		}
+		for _, attr := range springTopicPatternAttr.FindAllStringSubmatch(args, -1) {
+			index, err := strconv.Atoi(attr[1])
+			if err != nil || index >= len(literals) {
+				continue
+			}
+			literal := literals[index]
+			// A pattern may contain commas, so it is resolved but not split.
+			pattern, ok := props.resolve(literal.Value, 0)
+			if !ok || strings.TrimSpace(pattern) == "" || strings.Contains(pattern, "#{") {
+				continue
+			}
+			patterns = append(patterns, PatternReference{Pattern: strings.TrimSpace(pattern), Line: literal.Line, Column: literal.Column, Source: SourceSpring, Direction: DirectionConsume})
+		}
+	}
+
+	for _, call := range springCallPatterns {
+		for _, match := range call.pattern.FindAllStringSubmatch(masked, -1) {
+			index, err := strconv.Atoi(match[1])
+			if err == nil {
+				emit(index, call.direction)
+			}
+		}
+	}
+
+	return refs, patterns
+}
+
+// maskLiterals replaces each literal with \x00<index>\x00.
+func maskLiterals(content []byte, literals []stringLiteral) string {
+	var masked strings.Builder
+	last := 0
+	for i, literal := range literals {
+		masked.Write(content[last:literal.Offset])
+		masked.WriteString("\x00" + strconv.Itoa(i) + "\x00")
+		last = literal.End
+	}
+	masked.Write(content[last:])
+	return masked.String()
+}
+
+func maskedLiteralIndexes(text string) []int {
+	matches := springMaskedLiteralPattern.FindAllStringSubmatch(text, -1)
+	out := make([]int, 0, len(matches))
+	for _, match := range matches {
+		if index, err := strconv.Atoi(match[1]); err == nil {
+			out = append(out, index)
+		}
+	}
+	return out
+}
+
+// matchingParen returns the index of the parenthesis closing src[open].
+func matchingParen(src string, open int) int {
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch src[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(src)
+}
+
+// resolveTopics expands ${key} and ${key:default} placeholders and splits
+// comma-separated lists. Values that stay unresolved or contain SpEL are
+// dropped.
+func (p springProperties) resolveTopics(value string) []string {
+	resolved, ok := p.resolve(value, 0)
+	if !ok || strings.Contains(resolved, "#{") {
+		return nil
+	}
+
+	out := make([]string, 0, 1)
+	for _, part := range strings.Split(resolved, ",") {
+		topic := strings.TrimSpace(part)
+		if topicLiteralPattern.MatchString(topic) {
+			out = append(out, topic)
+		}
+	}
+	return out
+}
+
+func (p springProperties) resolve(value string, depth int) (string, bool) {
+	if depth > 10 {
+		return "", false
+	}
+
+	ok := true
+	resolved := springPlaceholderPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		match := springPlaceholderPattern.FindStringSubmatch(placeholder)
+		key := strings.TrimSpace(match[1])
+		if propValue, exists := p[key]; exists {
+			nested, nestedOK := p.resolve(propValue, depth+1)
+			if nestedOK {
+				return nested
+			}
+		}
+		if strings.Contains(placeholder, ":") {
+			return match[2]
+		}
+		ok = false
+		return placeholder
+	})
+	return resolved, ok
+}
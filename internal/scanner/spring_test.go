@@ -0,0 +1,127 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSpringPropertiesResolveTopics(t *testing.T) {
+	props := springProperties{
+		"app.topics.orders": "orders.created",
+		"app.topics.all":    "${app.topics.orders}, audit.log",
+		"app.env":           "prod",
+	}
+
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "${app.topics.orders}", want: []string{"orders.created"}},
+		{value: "${app.topics.all}", want: []string{"orders.created", "audit.log"}},
+		{value: "${missing.key:fallback.topic}", want: []string{"fallback.topic"}},
+		{value: "events.${app.env}", want: []string{"events.prod"}},
+		{value: "${missing.key}", want: nil},
+		{value: "#{'${app.topics.orders}'.split(',')}", want: nil},
+	}
+
+	for _, tt := range tests {
+		got := props.resolveTopics(tt.value)
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("resolveTopics(%q) = %#v, want %#v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRepoScannerScanSpring(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "src", "main", "resources", "application.yml"), `app:
+  topics:
+    orders: orders.created
+spring:
+  cloud:
+    stream:
+      bindings:
+        process-in-0:
+          destination: payments.requested
+        process-out-0:
+          destination: payments.settled
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "src", "main", "resources", "application-dev.properties"), `app.topics.orders=orders.dev
+app.topics.audit=audit.log
+app.topics.replay=replay\\..*
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "src", "main", "java", "OrderService.java"), `package shop;
+
+public class OrderService {
+    @KafkaListener(topics = {"${app.topics.orders}", "inventory.changed"}, groupId = "shop")
+    public void onOrder(String payload) {
+        kafkaTemplate.send("${app.topics.audit}", payload);
+    }
+
+    @KafkaListener(topicPattern = "${app.topics.replay}", groupId = "shop")
+    public void onReplay(String payload) {}
+
+    @Bean
+    public NewTopic shipments() {
+        return TopicBuilder.name("shipments.v1").partitions(3).build();
+    }
+}
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	tests := []struct {
+		topic         string
+		file          string
+		line          int
+		direction     string
+		literalColumn int
+	}{
+		{topic: "orders.created", file: "src/main/java/OrderService.java", line: 4, direction: DirectionConsume},
+		{topic: "inventory.changed", file: "src/main/java/OrderService.java", line: 4, direction: DirectionConsume, literalColumn: 55},
+		{topic: "audit.log", file: "src/main/java/OrderService.java", line: 6, direction: DirectionProduce},
+		{topic: "shipments.v1", file: "src/main/java/OrderService.java", line: 14, direction: DirectionAdmin, literalColumn: 35},
+		{topic: "payments.requested", file: "src/main/resources/application.yml", line: 9, direction: DirectionConsume},
+		{topic: "payments.settled", file: "src/main/resources/application.yml", line: 11, direction: DirectionProduce},
+	}
+	for _, tt := range tests {
+		ref, ok := findSourceReference(result, tt.topic, SourceSpring)
+		if !ok {
+			t.Fatalf("expected spring reference for %q", tt.topic)
+		}
+		if ref.File != tt.file || ref.Line != tt.line || ref.Direction != tt.direction || ref.LiteralColumn != tt.literalColumn {
+			t.Fatalf("reference for %q = %#v, want %s:%d (%s), literal column %d", tt.topic, ref, tt.file, tt.line, tt.direction, tt.literalColumn)
+		}
+	}
+
+	wantPattern := PatternReference{Pattern: `replay\..*`, File: "src/main/java/OrderService.java", Line: 9, Column: 36, Source: SourceSpring, Direction: DirectionConsume}
+	if !containsPattern(result.Patterns, wantPattern) {
+		t.Fatalf("patterns = %#v, want %#v", result.Patterns, wantPattern)
+	}
+
+	if _, ok := findSourceReference(result, "orders.dev", SourceSpring); ok {
+		t.Fatalf("profile property should not override application.yml value")
+	}
+	for _, occ := range result.Topics["inventory.changed"].Occurrences {
+		if occ.Source != SourceSpring {
+			t.Fatalf("heuristic reference %#v should be shadowed by the spring reference", occ)
+		}
+	}
+}
+
+func containsPattern(patterns []PatternReference, want PatternReference) bool {
+	for _, pattern := range patterns {
+		if pattern == want {
+			return true
+		}
+	}
+	return false
+}
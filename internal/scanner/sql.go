@@ -0,0 +1,355 @@
+package scanner
+
+import (
+	"strings"
+)
+
+// Sources for SQL stream processors.
+const (
+	SourceKSQL     = "ksql"
+	SourceFlinkSQL = "flink_sql"
+)
+
+// sqlTokenKind identifies the shape of a lexed SQL token.
+type sqlTokenKind int
+
+const (
+	sqlIdent sqlTokenKind = iota
+	sqlQuotedIdent
+	sqlString
+	sqlPunct
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+	line int
+}
+
+// keyword reports whether the token is the unquoted keyword kw.
+func (t sqlToken) keyword(kw string) bool {
+	return t.kind == sqlIdent && strings.EqualFold(t.text, kw)
+}
+
+func (t sqlToken) punct(p string) bool {
+	return t.kind == sqlPunct && t.text == p
+}
+
+// sqlRelation is a stream or table created in a SQL file together with the
+// topics backing it.
+type sqlRelation struct {
+	source   string
+	topics   []sqlToken
+	patterns []sqlToken
+}
+
+// scanSQLFile extracts topics from ksqlDB and Flink SQL DDL:
+//
+//	CREATE STREAM orders (...) WITH (KAFKA_TOPIC='orders', ...);
+//	CREATE TABLE orders (...) WITH ('connector' = 'kafka', 'topic' = 'orders');
+//
+// ksqlDB streams and tables over an existing topic are consumed, and those
+// created AS SELECT produce their topic (the upper-cased name when
+// KAFKA_TOPIC is not set). Flink tables take their direction from INSERT
+// INTO (produce) and FROM/JOIN (consume) statements in the same file;
+// 'topic-pattern' becomes a subscription pattern.
+func scanSQLFile(content []byte) ([]Reference, []PatternReference) {
+	refs := make([]Reference, 0)
+	patterns := make([]PatternReference, 0)
+	relations := make(map[string]sqlRelation)
+
+	addRelationRefs := func(relation sqlRelation, line int, direction string) {
+		for _, topic := range relation.topics {
+			refs = append(refs, Reference{Topic: topic.text, Line: line, Source: relation.source, Direction: direction})
+		}
+		for _, pattern := range relation.patterns {
+			patterns = append(patterns, PatternReference{Pattern: pattern.text, Line: line, Source: relation.source, Direction: direction})
+		}
+	}
+
+	for _, statement := range splitSQLStatements(lexSQL(content)) {
+		if len(statement) == 0 {
+			continue
+		}
+		switch {
+		case statement[0].keyword("CREATE"):
+			name, relation, direction, ok := parseSQLCreate(statement)
+			if !ok {
+				continue
+			}
+			for _, topic := range relation.topics {
+				refs = append(refs, Reference{Topic: topic.text, Line: topic.line, Source: relation.source, Direction: direction})
+			}
+			for _, pattern := range relation.patterns {
+				patterns = append(patterns, PatternReference{Pattern: pattern.text, Line: pattern.line, Source: relation.source, Direction: direction})
+			}
+			if direction == DirectionProduce {
+				for _, source := range sqlSourceNames(statement) {
+					if sourceRelation, ok := relations[sqlRelationKey(source)]; ok {
+						addRelationRefs(sourceRelation, source.line, DirectionConsume)
+					}
+				}
+			}
+			relations[sqlRelationKey(name)] = relation
+		case statement[0].keyword("INSERT"):
+			if len(statement) > 2 && statement[1].keyword("INTO") {
+				if relation, ok := relations[sqlRelationKey(statement[2])]; ok {
+					addRelationRefs(relation, statement[2].line, DirectionProduce)
+				}
+			}
+			for _, source := range sqlSourceNames(statement) {
+				if relation, ok := relations[sqlRelationKey(source)]; ok {
+					addRelationRefs(relation, source.line, DirectionConsume)
+				}
+			}
+		case statement[0].keyword("SELECT"):
+			for _, source := range sqlSourceNames(statement) {
+				if relation, ok := relations[sqlRelationKey(source)]; ok {
+					addRelationRefs(relation, source.line, DirectionConsume)
+				}
+			}
+		}
+	}
+
+	return refs, patterns
+}
+
+// parseSQLCreate reads a CREATE [OR REPLACE] [SOURCE|TEMPORARY] STREAM|TABLE
+// statement and returns the relation's name, its topics and the direction
+// the statement itself implies.
+func parseSQLCreate(statement []sqlToken) (sqlToken, sqlRelation, string, bool) {
+	pos := 1
+	for pos < len(statement) && (statement[pos].keyword("OR") || statement[pos].keyword("REPLACE") ||
+		statement[pos].keyword("SOURCE") || statement[pos].keyword("TEMPORARY") || statement[pos].keyword("TEMP")) {
+		pos++
+	}
+	if pos >= len(statement) || !(statement[pos].keyword("STREAM") || statement[pos].keyword("TABLE")) {
+		return sqlToken{}, sqlRelation{}, "", false
+	}
+	pos++
+	if pos+2 < len(statement) && statement[pos].keyword("IF") && statement[pos+1].keyword("NOT") && statement[pos+2].keyword("EXISTS") {
+		pos += 3
+	}
+	if pos >= len(statement) || (statement[pos].kind != sqlIdent && statement[pos].kind != sqlQuotedIdent) {
+		return sqlToken{}, sqlRelation{}, "", false
+	}
+	name := statement[pos]
+	// Qualified Flink names (catalog.db.table) are keyed by their last part.
+	for pos+2 < len(statement) && statement[pos+1].punct(".") {
+		pos += 2
+		name = statement[pos]
+	}
+	pos++
+
+	// Top-level WITH (...) options and AS SELECT, outside column lists.
+	var (
+		options  map[string]sqlToken
+		asSelect bool
+		depth    int
+	)
+	for ; pos < len(statement); pos++ {
+		token := statement[pos]
+		switch {
+		case token.punct("("):
+			depth++
+		case token.punct(")"):
+			depth--
+		case depth == 0 && token.keyword("WITH") && options == nil && pos+1 < len(statement) && statement[pos+1].punct("("):
+			options = parseSQLOptions(statement[pos+2:])
+		case depth == 0 && token.keyword("AS") && pos+1 < len(statement) && (statement[pos+1].keyword("SELECT") || statement[pos+1].punct("(")):
+			asSelect = true
+		}
+		if asSelect {
+			break
+		}
+	}
+
+	if connector, ok := options["connector"]; ok {
+		if connector.text != "kafka" && connector.text != "upsert-kafka" {
+			return sqlToken{}, sqlRelation{}, "", false
+		}
+		relation := sqlRelation{source: SourceFlinkSQL}
+		if topic, ok := options["topic"]; ok {
+			relation.topics = splitSQLOption(topic, ";")
+		}
+		if pattern, ok := options["topic-pattern"]; ok {
+			relation.patterns = splitSQLOption(pattern, "")
+		}
+		direction := DirectionUnknown
+		if asSelect {
+			direction = DirectionProduce
+		}
+		return name, relation, direction, len(relation.topics) > 0 || len(relation.patterns) > 0
+	}
+
+	relation := sqlRelation{source: SourceKSQL}
+	if topic, ok := options["kafka_topic"]; ok {
+		relation.topics = splitSQLOption(topic, "")
+	} else if asSelect {
+		// ksqlDB names the sink topic of CREATE STREAM/TABLE AS SELECT
+		// after the relation.
+		implicit := name
+		if name.kind == sqlIdent {
+			implicit.text = strings.ToUpper(name.text)
+		}
+		relation.topics = []sqlToken{implicit}
+	}
+	if len(relation.topics) == 0 {
+		return sqlToken{}, sqlRelation{}, "", false
+	}
+	if asSelect {
+		return name, relation, DirectionProduce, true
+	}
+	return name, relation, DirectionConsume, true
+}
+
+// parseSQLOptions reads key = value pairs up to the closing parenthesis.
+// Keys are lower-cased; values keep their token so they carry a line.
+func parseSQLOptions(tokens []sqlToken) map[string]sqlToken {
+	options := make(map[string]sqlToken)
+	for pos := 0; pos+2 < len(tokens) && !tokens[pos].punct(")"); pos++ {
+		if !tokens[pos+1].punct("=") {
+			continue
+		}
+		key := tokens[pos]
+		if key.kind == sqlPunct {
+			continue
+		}
+		value := tokens[pos+2]
+		if value.kind == sqlPunct {
+			continue
+		}
+		options[strings.ToLower(key.text)] = value
+		pos += 2
+	}
+	return options
+}
+
+func splitSQLOption(value sqlToken, separator string) []sqlToken {
+	parts := []string{value.text}
+	if separator != "" {
+		parts = strings.Split(value.text, separator)
+	}
+	var tokens []sqlToken
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			tokens = append(tokens, sqlToken{kind: sqlString, text: part, line: value.line})
+		}
+	}
+	return tokens
+}
+
+// sqlSourceNames returns the relations read by FROM and JOIN clauses.
+func sqlSourceNames(statement []sqlToken) []sqlToken {
+	var names []sqlToken
+	for pos := 0; pos+1 < len(statement); pos++ {
+		if !statement[pos].keyword("FROM") && !statement[pos].keyword("JOIN") {
+			continue
+		}
+		name := statement[pos+1]
+		if name.kind != sqlIdent && name.kind != sqlQuotedIdent {
+			continue
+		}
+		for pos+3 < len(statement) && statement[pos+2].punct(".") {
+			pos += 2
+			name = statement[pos+1]
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// sqlRelationKey folds unquoted identifiers, which SQL treats
+// case-insensitively.
+func sqlRelationKey(name sqlToken) string {
+	if name.kind == sqlQuotedIdent {
+		return name.text
+	}
+	return strings.ToUpper(name.text)
+}
+
+func splitSQLStatements(tokens []sqlToken) [][]sqlToken {
+	var (
+		statements [][]sqlToken
+		current    []sqlToken
+	)
+	for _, token := range tokens {
+		if token.punct(";") {
+			statements = append(statements, current)
+			current = nil
+			continue
+		}
+		current = append(current, token)
+	}
+	return append(statements, current)
+}
+
+// lexSQL splits SQL into identifiers, quoted identifiers ("..." and
+// `...`), string literals and punctuation, dropping comments and
+// whitespace.
+func lexSQL(content []byte) []sqlToken {
+	text := string(content)
+	tokens := make([]sqlToken, 0)
+	line := 1
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(text[i:], "--"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				end = len(text) - i - 2
+			}
+			line += strings.Count(text[i:i+2+end], "\n")
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`':
+			kind := sqlString
+			if c != '\'' {
+				kind = sqlQuotedIdent
+			}
+			start := line
+			var value strings.Builder
+			i++
+			for i < len(text) {
+				if text[i] == c {
+					// A doubled quote is an escaped quote.
+					if i+1 < len(text) && text[i+1] == c {
+						value.WriteByte(c)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				if text[i] == '\n' {
+					line++
+				}
+				value.WriteByte(text[i])
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: kind, text: value.String(), line: start})
+		case isSQLIdentByte(c):
+			start := i
+			for i < len(text) && isSQLIdentByte(text[i]) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlIdent, text: text[start:i], line: line})
+		default:
+			tokens = append(tokens, sqlToken{kind: sqlPunct, text: string(c), line: line})
+			i++
+		}
+	}
+	return tokens
+}
+
+func isSQLIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,113 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanSQLFileKSQL(t *testing.T) {
+	content := []byte(`-- Orders pipeline
+CREATE STREAM orders_raw (id VARCHAR KEY, amount DOUBLE)
+  WITH (KAFKA_TOPIC='orders', VALUE_FORMAT='JSON', PARTITIONS=6);
+
+CREATE OR REPLACE STREAM big_orders
+  WITH (kafka_topic = 'orders.big') AS
+  SELECT * FROM orders_raw WHERE amount > 100;
+
+/* implicit sink topic:
+   named after the stream */
+CREATE STREAM order_totals AS SELECT id, amount FROM ORDERS_RAW EMIT CHANGES;
+
+CREATE TABLE "Customers" (id VARCHAR PRIMARY KEY) WITH (KAFKA_TOPIC='crm.customers', FORMAT='AVRO');
+INSERT INTO big_orders SELECT * FROM orders_raw WHERE amount > 1000;
+CREATE TABLE order_counts AS
+  SELECT id, COUNT(*) FROM orders_raw GROUP BY id;
+`)
+
+	refs, patterns := scanSQLFile(content)
+
+	want := []Reference{
+		{Topic: "orders", Line: 3, Source: SourceKSQL, Direction: DirectionConsume},
+		{Topic: "orders.big", Line: 6, Source: SourceKSQL, Direction: DirectionProduce},
+		{Topic: "orders", Line: 7, Source: SourceKSQL, Direction: DirectionConsume},
+		{Topic: "ORDER_TOTALS", Line: 11, Source: SourceKSQL, Direction: DirectionProduce},
+		{Topic: "orders", Line: 11, Source: SourceKSQL, Direction: DirectionConsume},
+		{Topic: "crm.customers", Line: 13, Source: SourceKSQL, Direction: DirectionConsume},
+		{Topic: "orders.big", Line: 14, Source: SourceKSQL, Direction: DirectionProduce},
+		{Topic: "orders", Line: 14, Source: SourceKSQL, Direction: DirectionConsume},
+		{Topic: "ORDER_COUNTS", Line: 15, Source: SourceKSQL, Direction: DirectionProduce},
+		{Topic: "orders", Line: 16, Source: SourceKSQL, Direction: DirectionConsume},
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Fatalf("references = %#v, want %#v", refs, want)
+	}
+	if len(patterns) != 0 {
+		t.Fatalf("patterns = %#v, want none", patterns)
+	}
+}
+
+func TestScanSQLFileFlink(t *testing.T) {
+	content := []byte(`CREATE TABLE default_catalog.shop.clicks (
+  user_id STRING,
+  url STRING
+) WITH (
+  'connector' = 'kafka',
+  'topic' = 'clicks.web;clicks.mobile',
+  'properties.bootstrap.servers' = 'kafka:9092'
+);
+
+CREATE TEMPORARY TABLE audit_in (payload STRING) WITH (
+  'connector' = 'kafka',
+  'topic-pattern' = 'audit\..*'
+);
+
+CREATE TABLE click_counts (url STRING, cnt BIGINT) WITH (
+  'connector' = 'upsert-kafka',
+  'topic' = 'clicks.counts'
+);
+
+CREATE TABLE warehouse (id STRING) WITH ('connector' = 'jdbc', 'table-name' = 'topic');
+
+INSERT INTO click_counts
+SELECT url, COUNT(*) FROM clicks GROUP BY url;
+`)
+
+	refs, patterns := scanSQLFile(content)
+
+	want := []Reference{
+		{Topic: "clicks.web", Line: 6, Source: SourceFlinkSQL, Direction: DirectionUnknown},
+		{Topic: "clicks.mobile", Line: 6, Source: SourceFlinkSQL, Direction: DirectionUnknown},
+		{Topic: "clicks.counts", Line: 17, Source: SourceFlinkSQL, Direction: DirectionUnknown},
+		{Topic: "clicks.counts", Line: 22, Source: SourceFlinkSQL, Direction: DirectionProduce},
+		{Topic: "clicks.web", Line: 23, Source: SourceFlinkSQL, Direction: DirectionConsume},
+		{Topic: "clicks.mobile", Line: 23, Source: SourceFlinkSQL, Direction: DirectionConsume},
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Fatalf("references = %#v, want %#v", refs, want)
+	}
+	wantPatterns := []PatternReference{{Pattern: `audit\..*`, Line: 12, Source: SourceFlinkSQL, Direction: DirectionUnknown}}
+	if !reflect.DeepEqual(patterns, wantPatterns) {
+		t.Fatalf("patterns = %#v, want %#v", patterns, wantPatterns)
+	}
+}
+
+func TestRepoScannerScanSQL(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "ksql", "orders.ksql"), "CREATE STREAM s WITH (KAFKA_TOPIC='payments.settled', VALUE_FORMAT='JSON');\n")
+	mustWriteFile(t, filepath.Join(repoDir, "migrations", "001_init.sql"), "CREATE TABLE users (id INT PRIMARY KEY);\n")
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if result.FilesScanned != 2 || len(result.Topics) != 1 {
+		t.Fatalf("FilesScanned = %d, topics = %#v", result.FilesScanned, result.Topics)
+	}
+	ref := result.Topics["payments.settled"].Occurrences[0]
+	if ref.File != "ksql/orders.ksql" || ref.Line != 1 || ref.Source != SourceKSQL || ref.Direction != DirectionConsume {
+		t.Fatalf("reference = %#v", ref)
+	}
+}
@@ -0,0 +1,60 @@
+package scanner
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// SourceStrimzi marks topics declared by Strimzi KafkaTopic custom resources.
+const SourceStrimzi = "strimzi"
+
+// scanStrimziTopics extracts KafkaTopic resources from a (multi-document)
+// Kubernetes manifest. The topic name is spec.topicName when set, otherwise
+// metadata.name; the declaration points at the document's kind line.
+func scanStrimziTopics(content []byte) ([]Reference, []TopicDeclaration) {
+	if !bytes.Contains(content, []byte("KafkaTopic")) {
+		return nil, nil
+	}
+
+	refs := make([]Reference, 0)
+	decls := make([]TopicDeclaration, 0)
+	for _, doc := range parseYAMLDocuments(content) {
+		kind := doc.get("kind")
+		if strings.TrimSpace(kind.scalar()) != "KafkaTopic" {
+			continue
+		}
+		apiVersion := strings.TrimSpace(doc.get("apiVersion").scalar())
+		if apiVersion != "" && !strings.HasPrefix(apiVersion, "kafka.strimzi.io/") {
+			continue
+		}
+
+		nameNode := doc.lookup("spec", "topicName")
+		if strings.TrimSpace(nameNode.scalar()) == "" {
+			nameNode = doc.lookup("metadata", "name")
+		}
+		name := strings.TrimSpace(nameNode.scalar())
+		if name == "" {
+			continue
+		}
+
+		decl := TopicDeclaration{
+			Topic:  name,
+			Line:   kind.Line,
+			Source: SourceStrimzi,
+		}
+		spec := doc.get("spec")
+		if partitions, err := strconv.Atoi(strings.TrimSpace(spec.get("partitions").scalar())); err == nil {
+			decl.Partitions = partitions
+		}
+		if replicas, err := strconv.Atoi(strings.TrimSpace(spec.get("replicas").scalar())); err == nil {
+			decl.ReplicationFactor = replicas
+		}
+		decl.Config = manifestConfig(spec, "config")
+
+		decls = append(decls, decl)
+		refs = append(refs, Reference{Topic: name, Line: nameNode.Line, Source: SourceStrimzi, Direction: DirectionAdmin})
+	}
+
+	return refs, decls
+}
@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanStrimziTopics(t *testing.T) {
+	content := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-topic
+---
+apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaTopic
+metadata:
+  name: orders-events
+  labels:
+    strimzi.io/cluster: main
+spec:
+  topicName: orders.events
+  partitions: 12
+  replicas: 3
+  config:
+    retention.ms: 604800000
+    cleanup.policy: delete
+---
+apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaTopic
+metadata:
+  name: payments.v1
+spec:
+  partitions: 6
+`)
+
+	refs, decls := scanStrimziTopics(content)
+
+	want := []TopicDeclaration{
+		{
+			Topic:             "orders.events",
+			Line:              7,
+			Source:            SourceStrimzi,
+			Partitions:        12,
+			ReplicationFactor: 3,
+			Config:            map[string]string{"retention.ms": "604800000", "cleanup.policy": "delete"},
+		},
+		{
+			Topic:      "payments.v1",
+			Line:       21,
+			Source:     SourceStrimzi,
+			Partitions: 6,
+		},
+	}
+	if !reflect.DeepEqual(decls, want) {
+		t.Fatalf("declarations = %#v, want %#v", decls, want)
+	}
+
+	wantRefs := []Reference{
+		{Topic: "orders.events", Line: 13, Source: SourceStrimzi, Direction: DirectionAdmin},
+		{Topic: "payments.v1", Line: 23, Source: SourceStrimzi, Direction: DirectionAdmin},
+	}
+	if !reflect.DeepEqual(refs, wantRefs) {
+		t.Fatalf("references = %#v, want %#v", refs, wantRefs)
+	}
+}
+
+func TestRepoScannerScanStrimzi(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "k8s", "topics.yaml"), `apiVersion: kafka.strimzi.io/v1beta2
+kind: KafkaTopic
+metadata:
+  name: audit.log
+spec:
+  replicas: 2
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+
+	if len(result.Declarations) != 1 {
+		t.Fatalf("declarations = %d, want 1", len(result.Declarations))
+	}
+	decl := result.Declarations[0]
+	if decl.File != "k8s/topics.yaml" || decl.Line != 2 || decl.ReplicationFactor != 2 {
+		t.Fatalf("unexpected declaration: %+v", decl)
+	}
+	if !hasSource(result, "audit.log", SourceStrimzi) {
+		t.Fatalf("expected strimzi reference for audit.log")
+	}
+}
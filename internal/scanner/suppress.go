@@ -0,0 +1,209 @@
+package scanner
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Suppression is an inline kafkaspectre:ignore annotation. Line is the line
+// the annotation applies to, which is the following line for
+// kafkaspectre:ignore-next-line. Topics limits the annotation to the named
+// topics; an annotation without topic= applies to every reference on the
+// line.
+//
+// Topic marks a kafkaspectre:ignore-topic annotation instead: it applies to
+// no line and marks the cluster topics in Topics as intentionally not
+// referenced by the repo.
+type Suppression struct {
+	Repo   string   `json:"repo,omitempty"`
+	File   string   `json:"file"`
+	Line   int      `json:"line"`
+	Topics []string `json:"topics,omitempty"`
+	Reason string   `json:"reason,omitempty"`
+	Topic  bool     `json:"topic,omitempty"`
+}
+
+// SuppressedReference is a reference dropped by an inline annotation.
+type SuppressedReference struct {
+	Reference
+	Reason string `json:"reason,omitempty"`
+}
+
+var (
+	suppressionMarkerPattern = regexp.MustCompile(`kafkaspectre:ignore(-next-line|-topic)?\b(.*)$`)
+	suppressionOptionPattern = regexp.MustCompile(`(?:^|\s)(topic|reason)=`)
+	// suppressionCommentClosers are stripped from the end of an unquoted
+	// option value so block comments (/* ... */, <!-- ... -->) don't leak
+	// into the reason.
+	suppressionCommentClosers = []string{"*/", "-->", "#}", "*)"}
+)
+
+// parseSuppressions returns the inline annotations in a file. The marker is
+// matched anywhere on a line so it works with any comment syntax.
+func parseSuppressions(content []byte) []Suppression {
+	if !bytes.Contains(content, []byte("kafkaspectre:ignore")) {
+		return nil
+	}
+
+	var suppressions []Suppression
+	for idx, line := range strings.Split(string(content), "\n") {
+		match := suppressionMarkerPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		suppression := Suppression{Line: idx + 1}
+		switch match[1] {
+		case "-next-line":
+			suppression.Line++
+		case "-topic":
+			suppression.Topic = true
+		}
+		options := match[2]
+		bounds := suppressionOptionPattern.FindAllStringSubmatchIndex(options, -1)
+		for i, bound := range bounds {
+			end := len(options)
+			if i+1 < len(bounds) {
+				end = bounds[i+1][0]
+			}
+			value := suppressionValue(options[bound[1]:end])
+			switch options[bound[2]:bound[3]] {
+			case "topic":
+				for _, topic := range strings.Split(value, ",") {
+					if topic = strings.TrimSpace(topic); topic != "" {
+						suppression.Topics = append(suppression.Topics, topic)
+					}
+				}
+			case "reason":
+				suppression.Reason = value
+			}
+		}
+		if suppression.Topic && len(suppression.Topics) == 0 {
+			continue
+		}
+		suppressions = append(suppressions, suppression)
+	}
+	return suppressions
+}
+
+func suppressionValue(raw string) string {
+	value := strings.TrimSpace(raw)
+	for _, closer := range suppressionCommentClosers {
+		value = strings.TrimSpace(strings.TrimSuffix(value, closer))
+	}
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	return value
+}
+
+// applySuppressions moves references and patterns on annotated lines from
+// the result into result.Suppressed.
+func applySuppressions(result *Result) {
+	if len(result.Suppressions) == 0 {
+		return
+	}
+
+	byLine := make(map[string][]Suppression, len(result.Suppressions))
+	for _, suppression := range result.Suppressions {
+		if suppression.Topic {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", suppression.File, suppression.Line)
+		byLine[key] = append(byLine[key], suppression)
+	}
+	lookup := func(file string, line int, topic string) (Suppression, bool) {
+		for _, suppression := range byLine[fmt.Sprintf("%s:%d", file, line)] {
+			if suppression.matches(topic) {
+				return suppression, true
+			}
+		}
+		return Suppression{}, false
+	}
+
+	for topic, topicRef := range result.Topics {
+		kept := topicRef.Occurrences[:0]
+		for _, ref := range topicRef.Occurrences {
+			if suppression, ok := lookup(ref.File, ref.Line, ref.Topic); ok {
+				result.Suppressed = append(result.Suppressed, SuppressedReference{Reference: ref, Reason: suppression.Reason})
+				continue
+			}
+			kept = append(kept, ref)
+		}
+		topicRef.Occurrences = kept
+		if len(kept) == 0 {
+			delete(result.Topics, topic)
+		}
+	}
+
+	patterns := result.Patterns[:0]
+	for _, pattern := range result.Patterns {
+		if suppression, ok := lookup(pattern.File, pattern.Line, pattern.Pattern); ok {
+			result.Suppressed = append(result.Suppressed, SuppressedReference{
+				Reference: Reference{
+					Topic:      pattern.Pattern,
+					File:       pattern.File,
+					Line:       pattern.Line,
+					Column:     pattern.Column,
+					Source:     pattern.Source,
+					Direction:  pattern.Direction,
+					Pattern:    pattern.Pattern,
+					Confidence: PatternConfidence,
+				},
+				Reason: suppression.Reason,
+			})
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	result.Patterns = patterns
+
+	sort.Slice(result.Suppressed, func(i, j int) bool {
+		left := result.Suppressed[i]
+		right := result.Suppressed[j]
+		if left.File != right.File {
+			return left.File < right.File
+		}
+		if left.Line != right.Line {
+			return left.Line < right.Line
+		}
+		return left.Topic < right.Topic
+	})
+}
+
+func (s Suppression) matches(topic string) bool {
+	if len(s.Topics) == 0 {
+		return true
+	}
+	for _, candidate := range s.Topics {
+		if candidate == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// SuppressedTopics returns the topics named by kafkaspectre:ignore-topic
+// annotations, keyed to the first annotation naming each. check uses them
+// to silence findings for cluster topics that are intentionally not
+// referenced in the repo; line annotations with topic= only drop
+// references.
+func SuppressedTopics(result *Result) map[string]Suppression {
+	topics := make(map[string]Suppression)
+	for _, suppression := range result.Suppressions {
+		if !suppression.Topic {
+			continue
+		}
+		for _, topic := range suppression.Topics {
+			if _, exists := topics[topic]; !exists {
+				topics[topic] = suppression
+			}
+		}
+	}
+	return topics
+}
@@ -0,0 +1,87 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	content := []byte(`producer.send("orders.created") // kafkaspectre:ignore
+# kafkaspectre:ignore-next-line topic=audit.events,audit.dlq reason=legacy replay topic
+topic: audit.events
+/* kafkaspectre:ignore reason="not a topic" */
+<!-- kafkaspectre:ignored -->
+# kafkaspectre:ignore-topic topic=orders.replay reason=replayed manually
+# kafkaspectre:ignore-topic reason=no topics named
+`)
+
+	got := parseSuppressions(content)
+	want := []Suppression{
+		{Line: 1},
+		{Line: 3, Topics: []string{"audit.events", "audit.dlq"}, Reason: "legacy replay topic"},
+		{Line: 4, Reason: "not a topic"},
+		{Line: 6, Topics: []string{"orders.replay"}, Reason: "replayed manually", Topic: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseSuppressions() = %#v, want %#v", got, want)
+	}
+}
+
+func TestScanAppliesSuppressions(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "config.yaml"), `kafka:
+  # kafkaspectre:ignore-next-line reason=fixture
+  topic: orders.fixture
+  output_topic: orders.created
+  topics: [orders.audit, orders.keep] # kafkaspectre:ignore topic=orders.audit
+  retry_topic: orders.retry # kafkaspectre:ignore-topic topic=orders.retry,orders.replay
+`)
+	mustWriteFile(t, filepath.Join(repoDir, ".env"), "KAFKA_TOPIC=payments.events # kafkaspectre:ignore\n")
+	mustWriteFile(t, filepath.Join(repoDir, "consumer.py"), `# kafkaspectre:ignore-next-line
+consumer.subscribe(pattern="^legacy\\..*")
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	for _, topic := range []string{"orders.fixture", "orders.audit", "payments.events"} {
+		if _, ok := result.Topics[topic]; ok {
+			t.Fatalf("topic %q should be suppressed", topic)
+		}
+	}
+	for _, topic := range []string{"orders.created", "orders.keep", "orders.retry"} {
+		if _, ok := result.Topics[topic]; !ok {
+			t.Fatalf("topic %q should be kept", topic)
+		}
+	}
+	if len(result.Patterns) != 0 {
+		t.Fatalf("patterns = %#v, want suppressed", result.Patterns)
+	}
+
+	suppressed := make(map[string]SuppressedReference, len(result.Suppressed))
+	for _, ref := range result.Suppressed {
+		suppressed[ref.Topic] = ref
+	}
+	if ref := suppressed["orders.fixture"]; ref.File != "config.yaml" || ref.Line != 3 || ref.Reason != "fixture" {
+		t.Fatalf("orders.fixture suppression = %#v", ref)
+	}
+	if _, ok := suppressed[`^legacy\..*`]; !ok {
+		t.Fatalf("expected suppressed pattern, got %#v", result.Suppressed)
+	}
+	if len(result.Suppressions) != 5 {
+		t.Fatalf("suppressions = %#v, want 5", result.Suppressions)
+	}
+
+	// Only ignore-topic annotations mark cluster topics as unreferenced.
+	topics := SuppressedTopics(result)
+	if len(topics) != 2 || topics["orders.replay"].Line != 6 {
+		t.Fatalf("SuppressedTopics() = %#v, want orders.retry and orders.replay", topics)
+	}
+	if _, ok := topics["orders.audit"]; ok {
+		t.Fatalf("a line annotation must not suppress the cluster topic orders.audit")
+	}
+}
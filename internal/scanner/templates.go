@@ -0,0 +1,348 @@
+package scanner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// templatePlaceholderPattern matches {name} placeholders in a templated
+	// topic such as "{env}.orders.v1".
+	templatePlaceholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+	identifierPattern          = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+	interpolationPattern       = regexp.MustCompile(`\$\{([^{}]*)\}|#\{([^{}]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)|\{([^{}]*)\}`)
+	printfVerbPattern          = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?([a-zA-Z%])`)
+	braceFieldPattern          = regexp.MustCompile(`\{([^{}]*)\}`)
+
+	javaFormatCallPattern   = regexp.MustCompile(`String\s*\.\s*format\s*\(\s*"`)
+	pythonFStringPattern    = regexp.MustCompile(`\b[fF]("|')`)
+	pythonFormatCallPattern = regexp.MustCompile(`^\s*\.\s*format\s*\(`)
+	pythonPercentPattern    = regexp.MustCompile(`^\s*%\s*`)
+)
+
+// IsTemplateTopic reports whether a topic is a template with {name}
+// placeholders, as produced for fmt.Sprintf("%s.orders", env) and similar.
+func IsTemplateTopic(topic string) bool {
+	return templatePlaceholderPattern.MatchString(topic)
+}
+
+// ExpandTemplates substitutes vars into templated topics, matching
+// placeholder names case-insensitively. Templates whose placeholders are all
+// mapped are merged into the concrete topic; the rest are left as they are.
+func ExpandTemplates(result *Result, vars map[string]string) {
+	if len(vars) == 0 {
+		return
+	}
+	lookup := make(map[string]string, len(vars))
+	for key, value := range vars {
+		lookup[strings.ToLower(key)] = value
+	}
+
+	for template, topicRef := range result.Topics {
+		if !IsTemplateTopic(template) {
+			continue
+		}
+
+		resolved := true
+		topic := templatePlaceholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+			value, ok := lookup[strings.ToLower(placeholder[1:len(placeholder)-1])]
+			if !ok {
+				resolved = false
+				return placeholder
+			}
+			return value
+		})
+		if !resolved {
+			continue
+		}
+
+		delete(result.Topics, template)
+		target, exists := result.Topics[topic]
+		if !exists {
+			target = &TopicReference{Topic: topic}
+			result.Topics[topic] = target
+		}
+		for _, ref := range topicRef.Occurrences {
+			ref.Topic = topic
+			ref.Template = template
+			ref.LiteralColumn = 0
+			target.Occurrences = append(target.Occurrences, ref)
+		}
+	}
+}
+
+// isTemplateTopicCandidate reports whether a template would be a valid topic
+// name once its placeholders are filled in.
+func isTemplateTopicCandidate(template string) bool {
+	if !IsTemplateTopic(template) {
+		return false
+	}
+	literal := templatePlaceholderPattern.ReplaceAllString(template, "")
+	if !strings.ContainsFunc(literal, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+		return false
+	}
+	return topicLiteralPattern.MatchString(templatePlaceholderPattern.ReplaceAllString(template, "x"))
+}
+
+// templatePlaceholderName names a placeholder after the last identifier in
+// the expression that fills it: env, cfg.Env and os.getenv("ENV") give env,
+// Env and ENV.
+func templatePlaceholderName(expr string) string {
+	names := identifierPattern.FindAllString(expr, -1)
+	if len(names) == 0 {
+		return ""
+	}
+	return names[len(names)-1]
+}
+
+// placeholder wraps a name as a template placeholder.
+func placeholder(name string) string {
+	return "{" + name + "}"
+}
+
+// printfTemplate renders a printf-style format with already rendered
+// arguments. Only %s, %v and %d are supported.
+func printfTemplate(format string, args []string) (string, bool) {
+	next := 0
+	ok := true
+	out := printfVerbPattern.ReplaceAllStringFunc(format, func(verb string) string {
+		switch verb[len(verb)-1] {
+		case '%':
+			return "%"
+		case 's', 'v', 'd':
+			if next >= len(args) {
+				ok = false
+				return verb
+			}
+			next++
+			return args[next-1]
+		default:
+			ok = false
+			return verb
+		}
+	})
+	return out, ok
+}
+
+// braceFormatTemplate renders str.format-style fields: {} and {0} take
+// positional arguments and {name} becomes a {name} placeholder.
+func braceFormatTemplate(format string, args []string) (string, bool) {
+	next := 0
+	ok := true
+	out := braceFieldPattern.ReplaceAllStringFunc(format, func(field string) string {
+		name := strings.TrimSpace(field[1 : len(field)-1])
+		if cut := strings.IndexAny(name, "!:"); cut >= 0 {
+			name = name[:cut]
+		}
+		switch {
+		case name == "":
+			if next >= len(args) {
+				ok = false
+				return field
+			}
+			next++
+			return args[next-1]
+		case name[0] >= '0' && name[0] <= '9':
+			index, err := strconv.Atoi(name)
+			if err != nil || index >= len(args) {
+				ok = false
+				return field
+			}
+			return args[index]
+		default:
+			return placeholder(templatePlaceholderName(name))
+		}
+	})
+	return out, ok
+}
+
+// interpolatedTemplate converts an interpolated literal ("${env}.orders",
+// "$env.orders", "#{env}.orders", $"{env}.orders") to {name} form.
+func interpolatedTemplate(value string) (string, bool) {
+	ok := true
+	out := interpolationPattern.ReplaceAllStringFunc(value, func(match string) string {
+		groups := interpolationPattern.FindStringSubmatch(match)
+		expr := groups[1] + groups[2] + groups[3] + groups[4]
+		if cut := strings.IndexAny(expr, ":,"); cut >= 0 && groups[4] != "" {
+			// C# format and alignment specifiers: {env:D2}, {env,5}.
+			expr = expr[:cut]
+		}
+		name := templatePlaceholderName(expr)
+		if name == "" {
+			ok = false
+			return match
+		}
+		return placeholder(name)
+	})
+	return out, ok && isTemplateTopicCandidate(out)
+}
+
+// templateArgument renders a call argument: string literals stand for
+// themselves, anything else becomes a placeholder.
+func templateArgument(arg string) string {
+	arg = strings.TrimSpace(arg)
+	if value, err := strconv.Unquote(arg); err == nil && len(arg) > 0 && arg[0] == '"' {
+		return value
+	}
+	if len(arg) >= 2 && arg[0] == '\'' && arg[len(arg)-1] == '\'' {
+		return arg[1 : len(arg)-1]
+	}
+	return placeholder(templatePlaceholderName(arg))
+}
+
+type sourceTemplate struct {
+	template string
+	// column is the 1-based byte column of the format string.
+	column int
+}
+
+// sourceFormatTemplates finds templated topic names built with Java's
+// String.format and Python's f-strings, str.format and % formatting.
+func sourceFormatTemplates(line string) []sourceTemplate {
+	out := make([]sourceTemplate, 0)
+	add := func(template string, ok bool, column int) {
+		if ok && isTemplateTopicCandidate(template) {
+			out = append(out, sourceTemplate{template: template, column: column})
+		}
+	}
+
+	for _, loc := range javaFormatCallPattern.FindAllStringIndex(line, -1) {
+		format, end, ok := readQuoted(line, loc[1]-1)
+		if !ok {
+			continue
+		}
+		args := splitCallArguments(line[end:])
+		rendered := make([]string, 0, len(args))
+		for _, arg := range args {
+			rendered = append(rendered, templateArgument(arg))
+		}
+		template, ok := printfTemplate(format, rendered)
+		add(template, ok, loc[1]+1)
+	}
+
+	for _, loc := range pythonFStringPattern.FindAllStringSubmatchIndex(line, -1) {
+		format, _, ok := readQuoted(line, loc[2])
+		if !ok {
+			continue
+		}
+		template, ok := braceFormatTemplate(format, nil)
+		add(template, ok, loc[2]+2)
+	}
+
+	for start := 0; start < len(line); start++ {
+		if line[start] != '"' && line[start] != '\'' {
+			continue
+		}
+		if start > 0 && (line[start-1] == 'f' || line[start-1] == 'F') {
+			_, end, ok := readQuoted(line, start)
+			if ok {
+				start = end - 1
+			}
+			continue
+		}
+		format, end, ok := readQuoted(line, start)
+		if !ok {
+			break
+		}
+		rest := line[end:]
+		switch {
+		case pythonFormatCallPattern.MatchString(rest):
+			open := strings.IndexByte(rest, '(')
+			args := splitCallArguments(rest[open+1:])
+			rendered := make([]string, 0, len(args))
+			for _, arg := range args {
+				if name, _, isKeyword := strings.Cut(arg, "="); isKeyword && identifierPattern.MatchString(name) {
+					continue
+				}
+				rendered = append(rendered, templateArgument(arg))
+			}
+			template, ok := braceFormatTemplate(format, rendered)
+			add(template, ok, start+2)
+		case pythonPercentPattern.MatchString(rest) && strings.Contains(format, "%"):
+			operand := strings.TrimSpace(pythonPercentPattern.ReplaceAllString(rest, ""))
+			var args []string
+			if strings.HasPrefix(operand, "(") {
+				args = splitCallArguments(operand[1:])
+			} else {
+				args = []string{identifierPattern.FindString(operand)}
+			}
+			rendered := make([]string, 0, len(args))
+			for _, arg := range args {
+				rendered = append(rendered, templateArgument(arg))
+			}
+			template, ok := printfTemplate(format, rendered)
+			add(template, ok, start+2)
+		}
+		start = end - 1
+	}
+
+	return out
+}
+
+// readQuoted reads the quoted string starting at line[start] and returns its
+// unescaped body and the index just past the closing quote.
+func readQuoted(line string, start int) (string, int, bool) {
+	if start >= len(line) {
+		return "", 0, false
+	}
+	quote := line[start]
+	var body strings.Builder
+	for i := start + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if i+1 < len(line) {
+				i++
+				body.WriteByte(line[i])
+			}
+		case quote:
+			return body.String(), i + 1, true
+		default:
+			body.WriteByte(line[i])
+		}
+	}
+	return "", 0, false
+}
+
+// splitCallArguments splits the remaining arguments of a call, starting just
+// after a comma or the opening parenthesis, at top-level commas.
+func splitCallArguments(rest string) []string {
+	args := make([]string, 0)
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				if arg := strings.TrimSpace(rest[start:i]); arg != "" {
+					args = append(args, arg)
+				}
+				return args
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				if arg := strings.TrimSpace(rest[start:i]); arg != "" {
+					args = append(args, arg)
+				}
+				start = i + 1
+			}
+		}
+	}
+	return args
+}
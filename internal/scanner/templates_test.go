@@ -0,0 +1,107 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSourceFormatTemplates(t *testing.T) {
+	tests := []struct {
+		line string
+		want []sourceTemplate
+	}{
+		{
+			line: `producer.send(new ProducerRecord<>(String.format("%s.orders.v1", env), payload));`,
+			want: []sourceTemplate{{template: "{env}.orders.v1", column: 51}},
+		},
+		{
+			line: `producer.produce(f"{settings.env}.orders.v1", value)`,
+			want: []sourceTemplate{{template: "{env}.orders.v1", column: 20}},
+		},
+		{
+			line: `consumer.subscribe(["{}.payments.{region}".format(os.getenv("APP_ENV"), region="eu")])`,
+			want: []sourceTemplate{{template: "{APP_ENV}.payments.{region}", column: 22}},
+		},
+		{
+			line: `topic = "%s.audit" % env`,
+			want: []sourceTemplate{{template: "{env}.audit", column: 10}},
+		},
+		{line: `log.info(String.format("sent %d records", count))`, want: []sourceTemplate{}},
+		{line: `producer.send(String.format("%x.orders", id))`, want: []sourceTemplate{}},
+	}
+
+	for _, tt := range tests {
+		if got := sourceFormatTemplates(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("sourceFormatTemplates(%q) = %#v, want %#v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestRepoScannerScanGoTemplates(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "go.mod"), "module example.com/app\n\ngo 1.22\n")
+	mustWriteFile(t, filepath.Join(repoDir, "main.go"), `package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func run(env string) {
+	orders := fmt.Sprintf("%s.orders.v1", env)
+	_, _ = kgo.NewClient(kgo.ConsumeTopics(orders, os.Getenv("REGION")+".payments"))
+}
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	for _, template := range []string{"{env}.orders.v1", "{REGION}.payments"} {
+		ref, ok := findSourceReference(result, template, SourceGoAST)
+		if !ok {
+			t.Fatalf("expected go_ast template %q, got %#v", template, result.Topics)
+		}
+		if ref.Template != template || ref.Direction != DirectionConsume {
+			t.Fatalf("reference = %#v", ref)
+		}
+	}
+}
+
+func TestExpandTemplates(t *testing.T) {
+	result := &Result{Topics: map[string]*TopicReference{
+		"{env}.orders.v1": {
+			Topic:       "{env}.orders.v1",
+			Occurrences: []Reference{{Topic: "{env}.orders.v1", File: "main.go", Line: 11, Source: SourceGoAST, Template: "{env}.orders.v1"}},
+		},
+		"prod.orders.v1": {
+			Topic:       "prod.orders.v1",
+			Occurrences: []Reference{{Topic: "prod.orders.v1", File: "app.yaml", Line: 2, Source: SourceYAMLJSON}},
+		},
+		"{region}.payments": {
+			Topic:       "{region}.payments",
+			Occurrences: []Reference{{Topic: "{region}.payments", File: "main.go", Line: 12, Source: SourceGoAST, Template: "{region}.payments"}},
+		},
+	}}
+
+	ExpandTemplates(result, map[string]string{"ENV": "prod"})
+
+	if _, ok := result.Topics["{env}.orders.v1"]; ok {
+		t.Fatalf("mapped template should be replaced")
+	}
+	want := []Reference{
+		{Topic: "prod.orders.v1", File: "app.yaml", Line: 2, Source: SourceYAMLJSON},
+		{Topic: "prod.orders.v1", File: "main.go", Line: 11, Source: SourceGoAST, Template: "{env}.orders.v1"},
+	}
+	if got := result.Topics["prod.orders.v1"].Occurrences; !reflect.DeepEqual(got, want) {
+		t.Fatalf("occurrences = %#v, want %#v", got, want)
+	}
+	if _, ok := result.Topics["{region}.payments"]; !ok {
+		t.Fatalf("template with unmapped placeholders should be kept")
+	}
+}
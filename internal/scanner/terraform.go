@@ -0,0 +1,212 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SourceTerraform marks topics declared by Terraform kafka_topic resources.
+const SourceTerraform = "terraform"
+
+var (
+	terraformResourcePattern = regexp.MustCompile(`^\s*resource\s+"(kafka_topic|confluent_kafka_topic)"\s+"[^"]*"\s*\{`)
+	terraformAttrPattern     = regexp.MustCompile(`^\s*"?([A-Za-z0-9_.-]+)"?\s*=\s*(.*?)\s*,?\s*$`)
+	terraformBlockPattern    = regexp.MustCompile(`^\s*(config)\s*=?\s*\{\s*$`)
+)
+
+// terraformTopic tracks the resource block currently being parsed.
+type terraformTopic struct {
+	decl     TopicDeclaration
+	nameLine int
+	depth    int
+	inConfig bool
+}
+
+// scanTerraformFile extracts topic declarations from kafka_topic (Mongey
+// provider) and confluent_kafka_topic resources. Only literal values are
+// used; names built from variables or interpolation are skipped.
+func scanTerraformFile(content []byte) ([]Reference, []TopicDeclaration, error) {
+	lines := bufio.NewScanner(bytes.NewReader(content))
+	lines.Buffer(make([]byte, 0, 64*1024), 2*1024*1024)
+
+	refs := make([]Reference, 0)
+	decls := make([]TopicDeclaration, 0)
+	var current *terraformTopic
+
+	finish := func() {
+		if current == nil {
+			return
+		}
+		if current.decl.Topic != "" {
+			if len(current.decl.Config) == 0 {
+				current.decl.Config = nil
+			}
+			decls = append(decls, current.decl)
+			refs = append(refs, Reference{Topic: current.decl.Topic, Line: current.nameLine, Source: SourceTerraform, Direction: DirectionAdmin})
+		}
+		current = nil
+	}
+
+	for lineNo := 1; lines.Scan(); lineNo++ {
+		line := stripHCLComment(lines.Text())
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if current == nil {
+			if terraformResourcePattern.MatchString(line) {
+				current = &terraformTopic{
+					decl: TopicDeclaration{
+						Line:   lineNo,
+						Source: SourceTerraform,
+						Config: make(map[string]string),
+					},
+				}
+				current.depth = hclBraceDelta(line)
+				if current.depth <= 0 {
+					finish()
+				}
+			}
+			continue
+		}
+
+		switch {
+		case current.depth == 1 && terraformBlockPattern.MatchString(line):
+			current.inConfig = true
+		case current.depth == 1:
+			match := terraformAttrPattern.FindStringSubmatch(line)
+			if len(match) == 3 {
+				applyTerraformAttr(current, match[1], match[2], lineNo)
+			}
+		case current.depth == 2 && current.inConfig:
+			match := terraformAttrPattern.FindStringSubmatch(line)
+			if len(match) == 3 {
+				if value, ok := hclLiteral(match[2]); ok {
+					current.decl.Config[match[1]] = value
+				}
+			}
+		}
+
+		current.depth += hclBraceDelta(line)
+		if current.depth < 2 {
+			current.inConfig = false
+		}
+		if current.depth <= 0 {
+			finish()
+		}
+	}
+
+	if err := lines.Err(); err != nil {
+		return nil, nil, err
+	}
+	finish()
+
+	return refs, decls, nil
+}
+
+func applyTerraformAttr(current *terraformTopic, key, value string, lineNo int) {
+	switch key {
+	case "name", "topic_name":
+		if name, ok := hclLiteral(value); ok && name != "" {
+			current.decl.Topic = name
+			current.nameLine = lineNo
+		}
+	case "partitions", "partitions_count":
+		if literal, ok := hclLiteral(value); ok {
+			if parsed, err := strconv.Atoi(literal); err == nil {
+				current.decl.Partitions = parsed
+			}
+		}
+	case "replication_factor":
+		if literal, ok := hclLiteral(value); ok {
+			if parsed, err := strconv.Atoi(literal); err == nil {
+				current.decl.ReplicationFactor = parsed
+			}
+		}
+	case "config":
+		// Single-line map: config = { "cleanup.policy" = "compact" }
+		inner := strings.TrimSpace(value)
+		if !strings.HasPrefix(inner, "{") || !strings.HasSuffix(inner, "}") {
+			return
+		}
+		inner = strings.TrimSuffix(strings.TrimPrefix(inner, "{"), "}")
+		for _, pair := range strings.Split(inner, ",") {
+			match := terraformAttrPattern.FindStringSubmatch(pair)
+			if len(match) != 3 {
+				continue
+			}
+			if literal, ok := hclLiteral(match[2]); ok {
+				current.decl.Config[match[1]] = literal
+			}
+		}
+	}
+}
+
+// hclLiteral returns the value of a quoted string, number or bool literal.
+func hclLiteral(value string) (string, bool) {
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), ","))
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		inner := value[1 : len(value)-1]
+		if strings.Contains(inner, "${") || strings.Contains(inner, "%{") {
+			return "", false
+		}
+		return inner, true
+	}
+	if value == "true" || value == "false" {
+		return value, true
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value, true
+	}
+	return "", false
+}
+
+func hclBraceDelta(line string) int {
+	delta := 0
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case '{':
+			if !inString {
+				delta++
+			}
+		case '}':
+			if !inString {
+				delta--
+			}
+		}
+	}
+	return delta
+}
+
+func stripHCLComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case '#':
+			if !inString {
+				return line[:i]
+			}
+		case '/':
+			if !inString && i+1 < len(line) && line[i+1] == '/' {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
@@ -0,0 +1,101 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanTerraformFile(t *testing.T) {
+	content := []byte(`variable "env" {}
+
+resource "kafka_topic" "orders" {
+  name               = "orders.events" # primary stream
+  replication_factor = 3
+  partitions         = 12
+
+  config = {
+    "retention.ms"   = "604800000"
+    "cleanup.policy" = "delete"
+  }
+}
+
+resource "confluent_kafka_topic" "payments" {
+  kafka_cluster {
+    id = confluent_kafka_cluster.main.id
+  }
+  topic_name       = "payments.v1"
+  partitions_count = 6
+  config = { "cleanup.policy" = "compact" }
+}
+
+resource "kafka_topic" "templated" {
+  name       = "${var.env}.audit"
+  partitions = 1
+}
+
+resource "kafka_acl" "ignored" {
+  resource_name = "orders.events"
+}
+`)
+
+	refs, decls, err := scanTerraformFile(content)
+	if err != nil {
+		t.Fatalf("scanTerraformFile() error = %v", err)
+	}
+
+	want := []TopicDeclaration{
+		{
+			Topic:             "orders.events",
+			Line:              3,
+			Source:            SourceTerraform,
+			Partitions:        12,
+			ReplicationFactor: 3,
+			Config:            map[string]string{"retention.ms": "604800000", "cleanup.policy": "delete"},
+		},
+		{
+			Topic:      "payments.v1",
+			Line:       14,
+			Source:     SourceTerraform,
+			Partitions: 6,
+			Config:     map[string]string{"cleanup.policy": "compact"},
+		},
+	}
+	if !reflect.DeepEqual(decls, want) {
+		t.Fatalf("declarations = %#v, want %#v", decls, want)
+	}
+
+	wantRefs := []Reference{
+		{Topic: "orders.events", Line: 4, Source: SourceTerraform, Direction: DirectionAdmin},
+		{Topic: "payments.v1", Line: 18, Source: SourceTerraform, Direction: DirectionAdmin},
+	}
+	if !reflect.DeepEqual(refs, wantRefs) {
+		t.Fatalf("references = %#v, want %#v", refs, wantRefs)
+	}
+}
+
+func TestRepoScannerScanTerraform(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "infra", "topics.tf"), `resource "kafka_topic" "orders" {
+  name       = "orders.events"
+  partitions = 3
+}
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan error: %v", err)
+	}
+
+	if len(result.Declarations) != 1 {
+		t.Fatalf("declarations = %d, want 1", len(result.Declarations))
+	}
+	decl := result.Declarations[0]
+	if decl.File != "infra/topics.tf" || decl.Line != 1 || decl.Partitions != 3 {
+		t.Fatalf("unexpected declaration: %+v", decl)
+	}
+	if !hasSource(result, "orders.events", SourceTerraform) {
+		t.Fatalf("expected terraform reference for orders.events")
+	}
+}
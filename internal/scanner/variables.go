@@ -0,0 +1,224 @@
+package scanner
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// VariableBinding is one step of a ${VAR} resolution: the variable, the value
+// it resolved to and where that value was defined.
+type VariableBinding struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Default bool   `json:"default,omitempty"`
+}
+
+// maxVariableExpansions bounds the number of topics one placeholder can
+// resolve to when a variable is defined with several values.
+const maxVariableExpansions = 16
+
+var (
+	topicTemplatePattern    = regexp.MustCompile(`[A-Za-z0-9._-]*(?:\$\{[^{}\s]+\}[A-Za-z0-9._-]*)+`)
+	variablePlaceholder     = regexp.MustCompile(`\$\{([^{}\s]+)\}`)
+	variableExprPattern     = regexp.MustCompile(`^\??([A-Za-z0-9_.]+)(?:(:-|-|:)(.*))?$`)
+	helmValuesFilePattern   = regexp.MustCompile(`^values(?:[-.][A-Za-z0-9_.-]+)?\.ya?ml$`)
+	environmentBlockKeyword = map[string]struct{}{"env": {}, "environment": {}, "extraEnv": {}}
+)
+
+// variableSet holds every definition seen for a variable name, in walk order.
+type variableSet map[string][]VariableBinding
+
+func (v variableSet) bind(name, value, file string, line int) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	for _, existing := range v[name] {
+		if existing.Value == value {
+			return
+		}
+	}
+	v[name] = append(v[name], VariableBinding{Name: name, Value: value, File: file, Line: line})
+}
+
+// bindings flattens the set, ordered by name and then definition order.
+func (v variableSet) bindings() []VariableBinding {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var bindings []VariableBinding
+	for _, name := range names {
+		bindings = append(bindings, v[name]...)
+	}
+	return bindings
+}
+
+// collectEnvVariables records KEY=value pairs from a .env file.
+func collectEnvVariables(vars variableSet, relPath string, content []byte) {
+	for i, line := range splitConfigLines(content) {
+		match := envLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if len(match) != 3 {
+			continue
+		}
+		vars.bind(match[1], unquoteYAMLScalar(stripInlineComment(match[2])), relPath, i+1)
+	}
+}
+
+// collectYAMLVariables records docker-compose environment: blocks,
+// Kubernetes env: lists, ConfigMap data and, for Helm values files, every
+// scalar under its dotted key.
+func collectYAMLVariables(vars variableSet, relPath string, content []byte) {
+	base := strings.ToLower(filepath.Base(relPath))
+	helmValues := helmValuesFilePattern.MatchString(base)
+
+	for _, doc := range parseYAMLDocuments(content) {
+		if doc.get("kind").scalar() == "ConfigMap" {
+			bindEnvironmentNode(vars, relPath, doc.get("data"))
+		}
+		collectEnvironmentBlocks(vars, relPath, doc)
+
+		if helmValues {
+			entries := make([]propertyEntry, 0)
+			doc.flatten("", &entries)
+			for _, entry := range entries {
+				vars.bind(entry.Key, entry.Value, relPath, entry.Line)
+			}
+		}
+	}
+}
+
+func collectEnvironmentBlocks(vars variableSet, relPath string, node *yamlNode) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yamlMapping:
+		for _, key := range node.Keys {
+			child := node.Fields[key]
+			if _, ok := environmentBlockKeyword[key]; ok {
+				bindEnvironmentNode(vars, relPath, child)
+			}
+			collectEnvironmentBlocks(vars, relPath, child)
+		}
+	case yamlSequence:
+		for _, item := range node.Items {
+			collectEnvironmentBlocks(vars, relPath, item)
+		}
+	}
+}
+
+// bindEnvironmentNode handles the three shapes of an environment block:
+// a KEY: value mapping, a list of "KEY=value" strings and a list of
+// {name, value} objects.
+func bindEnvironmentNode(vars variableSet, relPath string, node *yamlNode) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yamlMapping:
+		for _, key := range node.Keys {
+			if child := node.Fields[key]; child.Kind == yamlScalar {
+				vars.bind(key, child.Value, relPath, child.Line)
+			}
+		}
+	case yamlSequence:
+		for _, item := range node.Items {
+			switch item.Kind {
+			case yamlScalar:
+				if name, value, ok := strings.Cut(item.Value, "="); ok {
+					vars.bind(name, value, relPath, item.Line)
+				}
+			case yamlMapping:
+				value := item.get("value")
+				if name := item.get("name").scalar(); name != "" && value != nil && value.Kind == yamlScalar {
+					vars.bind(name, value.Value, relPath, value.Line)
+				}
+			}
+		}
+	}
+}
+
+type variableExpansion struct {
+	value string
+	chain []VariableBinding
+}
+
+// expand substitutes every placeholder in template, following nested
+// placeholders and ${VAR:-default} fallbacks. Templates that cannot be fully
+// resolved produce no expansions.
+func (v variableSet) expand(template string, depth int) []variableExpansion {
+	if depth > 10 {
+		return nil
+	}
+
+	loc := variablePlaceholder.FindStringSubmatchIndex(template)
+	if loc == nil {
+		return []variableExpansion{{value: template}}
+	}
+
+	match := variableExprPattern.FindStringSubmatch(template[loc[2]:loc[3]])
+	if match == nil {
+		return nil
+	}
+	name, operator, fallback := match[1], match[2], match[3]
+
+	candidates := v[name]
+	if len(candidates) == 0 && operator != "" {
+		candidates = []VariableBinding{{Name: name, Value: fallback, Default: true}}
+	}
+
+	out := make([]variableExpansion, 0, len(candidates))
+	for _, binding := range candidates {
+		for _, inner := range v.expand(binding.Value, depth+1) {
+			rest := template[:loc[0]] + inner.value + template[loc[1]:]
+			for _, tail := range v.expand(rest, depth+1) {
+				chain := append([]VariableBinding{binding}, inner.chain...)
+				out = append(out, variableExpansion{value: tail.value, chain: append(chain, tail.chain...)})
+				if len(out) >= maxVariableExpansions {
+					return out
+				}
+			}
+		}
+	}
+	return out
+}
+
+// resolvePlaceholderReferences replaces references whose topic is a ${VAR}
+// template with references to the topics the template resolves to.
+func resolvePlaceholderReferences(result *Result, dedupe map[string]map[string]struct{}, vars variableSet) {
+	templates := make([]string, 0)
+	for topic := range result.Topics {
+		if strings.Contains(topic, "${") {
+			templates = append(templates, topic)
+		}
+	}
+	sort.Strings(templates)
+
+	for _, template := range templates {
+		occurrences := result.Topics[template].Occurrences
+		delete(result.Topics, template)
+		delete(dedupe, template)
+
+		for _, expansion := range vars.expand(template, 0) {
+			for _, topic := range extractTopicCandidates(expansion.value) {
+				if strings.Contains(topic, "${") {
+					continue
+				}
+				for _, ref := range occurrences {
+					ref.Topic = topic
+					ref.Resolution = expansion.chain
+					ref.LiteralColumn = 0
+					ref.Confidence = 0
+					addReference(result, dedupe, ref)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVariableSetExpand(t *testing.T) {
+	vars := make(variableSet)
+	vars.bind("ENV", "prod", ".env", 1)
+	vars.bind("ORDERS_TOPIC", "orders.${ENV}", ".env", 2)
+
+	tests := []struct {
+		template string
+		want     []variableExpansion
+	}{
+		{
+			template: "${ORDERS_TOPIC}",
+			want: []variableExpansion{{
+				value: "orders.prod",
+				chain: []VariableBinding{
+					{Name: "ORDERS_TOPIC", Value: "orders.${ENV}", File: ".env", Line: 2},
+					{Name: "ENV", Value: "prod", File: ".env", Line: 1},
+				},
+			}},
+		},
+		{
+			template: "${AUDIT_TOPIC:-audit.log}",
+			want: []variableExpansion{{
+				value: "audit.log",
+				chain: []VariableBinding{{Name: "AUDIT_TOPIC", Value: "audit.log", Default: true}},
+			}},
+		},
+		{template: "${MISSING}", want: []variableExpansion{}},
+	}
+
+	for _, tt := range tests {
+		if got := vars.expand(tt.template, 0); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("expand(%q) = %#v, want %#v", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestRepoScannerScanResolvesPlaceholders(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, ".env"), `KAFKA_TOPIC=orders.created
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "docker-compose.yml"), `services:
+  worker:
+    environment:
+      - PAYMENTS_TOPIC=payments.v1
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "k8s", "deployment.yaml"), `apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          env:
+            - name: REGION
+              value: eu
+---
+apiVersion: v1
+kind: ConfigMap
+data:
+  AUDIT_TOPIC: audit.${REGION}
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "config", "app.yaml"), `kafka:
+  topic: ${KAFKA_TOPIC}
+  consumer_topics: ${PAYMENTS_TOPIC}, ${AUDIT_TOPIC}
+  dlq_topic: ${DLQ_TOPIC:-orders.dlq}
+  retry_topic: ${UNDEFINED_TOPIC}
+`)
+
+	result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	tests := []struct {
+		topic string
+		line  int
+		chain []VariableBinding
+	}{
+		{topic: "orders.created", line: 2, chain: []VariableBinding{{Name: "KAFKA_TOPIC", Value: "orders.created", File: ".env", Line: 1}}},
+		{topic: "payments.v1", line: 3, chain: []VariableBinding{{Name: "PAYMENTS_TOPIC", Value: "payments.v1", File: "docker-compose.yml", Line: 4}}},
+		{topic: "audit.eu", line: 3, chain: []VariableBinding{
+			{Name: "AUDIT_TOPIC", Value: "audit.${REGION}", File: "k8s/deployment.yaml", Line: 15},
+			{Name: "REGION", Value: "eu", File: "k8s/deployment.yaml", Line: 10},
+		}},
+		{topic: "orders.dlq", line: 4, chain: []VariableBinding{{Name: "DLQ_TOPIC", Value: "orders.dlq", Default: true}}},
+	}
+	for _, tt := range tests {
+		ref, ok := findSourceReference(result, tt.topic, SourceYAMLJSON)
+		if !ok {
+			t.Fatalf("expected resolved reference for %q", tt.topic)
+		}
+		if ref.File != "config/app.yaml" || ref.Line != tt.line || !reflect.DeepEqual(ref.Resolution, tt.chain) {
+			t.Fatalf("reference for %q = %#v", tt.topic, ref)
+		}
+	}
+
+	for topic := range result.Topics {
+		if topic == "${UNDEFINED_TOPIC}" || topic == "${KAFKA_TOPIC}" {
+			t.Fatalf("unresolved placeholder %q should not be reported", topic)
+		}
+	}
+}
+
+func TestRepoScannerScanFlowCollections(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, ".github", "workflows", "ci.yml"), `jobs:
+  test:
+    strategy:
+      matrix:
+        include: [os: linux, {os: darwin}]
+    env: {KAFKA_TOPIC: orders.created}
+`)
+	mustWriteFile(t, filepath.Join(repoDir, "config", "app.yaml"), `topics: [a: b]
+kafka:
+  topic: ${KAFKA_TOPIC}
+`)
+
+	done := make(chan *Result, 1)
+	go func() {
+		result, err := NewRepoScanner().Scan(context.Background(), repoDir)
+		if err != nil {
+			t.Errorf("Scan() error = %v", err)
+		}
+		done <- result
+	}()
+
+	select {
+	case result := <-done:
+		if result == nil || result.Topics["orders.created"] == nil {
+			t.Fatalf("expected orders.created resolved from the flow env mapping, got %#v", result)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Scan() did not return on flow collections")
+	}
+}
@@ -0,0 +1,287 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultMaxFileSize is the largest file the scanner reads unless
+// Config.MaxFileSize overrides it.
+const DefaultMaxFileSize = 2 * 1024 * 1024
+
+// Reasons recorded in Result.SkippedFiles and Result.SkippedDirs.
+const (
+	SkipReasonDefaultDir  = "default_dir"
+	SkipReasonIgnoreFile  = "ignore_file"
+	SkipReasonExcluded    = "excluded"
+	SkipReasonNotIncluded = "not_included"
+	SkipReasonTooLarge    = "too_large"
+	SkipReasonUnsupported = "unsupported"
+)
+
+// ignoreFileNames are read from every walked directory. Both use
+// .gitignore syntax.
+var ignoreFileNames = []string{".gitignore", ".kafkaspectreignore"}
+
+// Config controls which files RepoScanner walks.
+type Config struct {
+	// MaxFileSize skips files larger than this many bytes; 0 keeps
+	// DefaultMaxFileSize.
+	MaxFileSize int64
+	// Include limits scanning to files matching at least one glob.
+	Include []string
+	// Exclude skips files and directories matching any glob.
+	Exclude []string
+	// Workers is the number of files read and parsed concurrently; 0 uses
+	// GOMAXPROCS.
+	Workers int
+	// CacheDir enables the per-file scan cache when set.
+	CacheDir string
+	// ScanArchives opens .jar, .war, .whl and .zip files and scans their
+	// entries.
+	ScanArchives bool
+}
+
+// walkedFile is a file the walk selected for scanning.
+type walkedFile struct {
+	path    string
+	relPath string
+	mode    scanMode
+}
+
+// walk lists the files to scan in lexical order, applying the skip rules
+// and counting what it passes over in result.
+func (s *RepoScanner) walk(ctx context.Context, absRepoPath string, result *Result) ([]walkedFile, error) {
+	var (
+		files []walkedFile
+		rules ignoreRules
+	)
+	err := filepath.WalkDir(absRepoPath, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(absRepoPath, path)
+		if err != nil {
+			relPath = path
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			if relPath == "." {
+				rules, err = loadIgnoreFiles(rules, path, "")
+				return err
+			}
+			if reason := s.dirSkipReason(d.Name(), relPath, rules); reason != "" {
+				result.SkippedDirs[reason]++
+				return filepath.SkipDir
+			}
+			rules, err = loadIgnoreFiles(rules, path, relPath)
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		mode := detectScanMode(path)
+		if reason := s.fileSkipReason(relPath, mode, rules); reason != "" {
+			result.SkippedFiles[reason]++
+			return nil
+		}
+
+		fileInfo, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if fileInfo.Size() > s.sizeLimit(mode) {
+			result.SkippedFiles[SkipReasonTooLarge]++
+			return nil
+		}
+
+		files = append(files, walkedFile{path: path, relPath: relPath, mode: mode})
+		return nil
+	})
+	return files, err
+}
+
+// ignoreRule is one compiled .gitignore line. Its expression is anchored
+// to the directory holding the ignore file, so rules from different
+// directories can share one list.
+type ignoreRule struct {
+	expr    *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreRules evaluates rules in order; the last matching rule wins, as in
+// git.
+type ignoreRules []ignoreRule
+
+func (rules ignoreRules) ignored(relPath string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.expr.MatchString(relPath) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// loadIgnoreFiles appends the rules from the ignore files in dir, whose
+// repo-relative path is relDir ("" for the root).
+func loadIgnoreFiles(rules ignoreRules, dir, relDir string) (ignoreRules, error) {
+	for _, name := range ignoreFileNames {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return rules, fmt.Errorf("read %s: %w", path.Join(relDir, name), err)
+		}
+		rules = appendIgnoreRules(rules, content, relDir)
+	}
+	return rules, nil
+}
+
+func appendIgnoreRules(rules ignoreRules, content []byte, relDir string) ignoreRules {
+	for _, line := range strings.Split(string(content), "\n") {
+		if rule, ok := parseIgnoreLine(line, relDir); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// parseIgnoreLine compiles a .gitignore line. Blank lines, comments and
+// patterns that don't compile are skipped.
+func parseIgnoreLine(line, relDir string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	expr, err := compileGlob(line, relDir)
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.expr = expr
+	return rule, true
+}
+
+// compileGlob turns a .gitignore-style glob into an expression over
+// repo-relative slash paths. A glob without a slash matches at any depth
+// below relDir; one with a slash is anchored to relDir. "**" spans
+// directories.
+func compileGlob(glob, relDir string) (*regexp.Regexp, error) {
+	glob = strings.TrimRight(strings.TrimSpace(glob), "/")
+	if glob == "" {
+		return nil, errors.New("empty glob")
+	}
+
+	prefix := ""
+	if relDir != "" {
+		prefix = regexp.QuoteMeta(relDir + "/")
+	}
+	anchored := strings.Contains(glob, "/")
+	glob = strings.TrimPrefix(glob, "/")
+	if !anchored {
+		prefix += "(?:.*/)?"
+	}
+
+	var body strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if !strings.HasPrefix(glob[i:], "**") {
+				body.WriteString("[^/]*")
+				continue
+			}
+			if strings.HasPrefix(glob[i:], "**/") {
+				body.WriteString("(?:.*/)?")
+				i += 2
+				continue
+			}
+			body.WriteString(".*")
+			i++
+		case '?':
+			body.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end <= 0 {
+				body.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			body.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				body.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+			}
+		default:
+			body.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	expr, err := regexp.Compile("^" + prefix + body.String() + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %w", glob, err)
+	}
+	return expr, nil
+}
+
+func compileGlobs(globs []string) ([]*regexp.Regexp, error) {
+	exprs := make([]*regexp.Regexp, 0, len(globs))
+	for _, glob := range globs {
+		if strings.TrimSpace(glob) == "" {
+			continue
+		}
+		expr, err := compileGlob(glob, "")
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+func matchesAnyGlob(exprs []*regexp.Regexp, relPath string) bool {
+	for _, expr := range exprs {
+		if expr.MatchString(relPath) {
+			return true
+		}
+	}
+	return false
+}
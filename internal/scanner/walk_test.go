@@ -0,0 +1,120 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		glob   string
+		relDir string
+		path   string
+		want   bool
+	}{
+		{glob: "*.log", path: "app.log", want: true},
+		{glob: "*.log", path: "deep/dir/app.log", want: true},
+		{glob: "/build", path: "build", want: true},
+		{glob: "/build", path: "src/build", want: false},
+		{glob: "docs/*.md", path: "docs/a.md", want: true},
+		{glob: "docs/*.md", path: "docs/sub/a.md", want: false},
+		{glob: "**/testdata/**", path: "pkg/testdata/topics.yaml", want: true},
+		{glob: "a/**/b", path: "a/b", want: true},
+		{glob: "a/**/b", path: "a/x/y/b", want: true},
+		{glob: "file[0-9].yaml", path: "file7.yaml", want: true},
+		{glob: "file[!0-9].yaml", path: "file7.yaml", want: false},
+		{glob: "fixtures", relDir: "svc", path: "svc/test/fixtures", want: true},
+		{glob: "fixtures", relDir: "svc", path: "other/fixtures", want: false},
+	}
+
+	for _, tt := range tests {
+		expr, err := compileGlob(tt.glob, tt.relDir)
+		if err != nil {
+			t.Fatalf("compileGlob(%q) error = %v", tt.glob, err)
+		}
+		if got := expr.MatchString(tt.path); got != tt.want {
+			t.Fatalf("compileGlob(%q, %q) match %q = %v, want %v", tt.glob, tt.relDir, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestScanHonoursIgnoreFilesAndGlobs(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, ".gitignore"), "generated/\n*.local.yaml\n!keep.local.yaml\n")
+	mustWriteFile(t, filepath.Join(repoDir, "svc", ".kafkaspectreignore"), "# fixtures are not real topics\nfixtures.yaml\n")
+	mustWriteFile(t, filepath.Join(repoDir, "app.yaml"), "topic: orders.created\n")
+	mustWriteFile(t, filepath.Join(repoDir, "dev.local.yaml"), "topic: orders.dev\n")
+	mustWriteFile(t, filepath.Join(repoDir, "keep.local.yaml"), "topic: orders.keep\n")
+	mustWriteFile(t, filepath.Join(repoDir, "generated", "app.yaml"), "topic: orders.generated\n")
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "fixtures.yaml"), "topic: orders.fixture\n")
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "app.yaml"), "topic: orders.svc\n")
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "legacy", "app.yaml"), "topic: orders.legacy\n")
+	mustWriteFile(t, filepath.Join(repoDir, "svc", "big.yaml"), "topic: orders.big\n"+strings.Repeat("#", 2048)+"\n")
+	mustWriteFile(t, filepath.Join(repoDir, "README.md"), "orders.readme\n")
+
+	repoScanner, err := NewRepoScannerWithConfig(Config{
+		MaxFileSize: 1024,
+		Exclude:     []string{"svc/legacy"},
+	})
+	if err != nil {
+		t.Fatalf("NewRepoScannerWithConfig() error = %v", err)
+	}
+	result, err := repoScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	got := make([]string, 0, len(result.Topics))
+	for topic := range result.Topics {
+		got = append(got, topic)
+	}
+	for _, want := range []string{"orders.created", "orders.keep", "orders.svc"} {
+		if _, ok := result.Topics[want]; !ok {
+			t.Fatalf("expected topic %q, got %v", want, got)
+		}
+	}
+	if len(result.Topics) != 3 {
+		t.Fatalf("topics = %v, want 3", got)
+	}
+
+	wantFiles := map[string]int{
+		SkipReasonIgnoreFile:  2,
+		SkipReasonTooLarge:    1,
+		SkipReasonUnsupported: 3,
+	}
+	if !reflect.DeepEqual(result.SkippedFiles, wantFiles) {
+		t.Fatalf("SkippedFiles = %#v, want %#v", result.SkippedFiles, wantFiles)
+	}
+	wantDirs := map[string]int{SkipReasonIgnoreFile: 1, SkipReasonExcluded: 1}
+	if !reflect.DeepEqual(result.SkippedDirs, wantDirs) {
+		t.Fatalf("SkippedDirs = %#v, want %#v", result.SkippedDirs, wantDirs)
+	}
+}
+
+func TestScanIncludeGlobs(t *testing.T) {
+	repoDir := t.TempDir()
+	mustWriteFile(t, filepath.Join(repoDir, "src", "app.yaml"), "topic: orders.created\n")
+	mustWriteFile(t, filepath.Join(repoDir, "deploy", "app.yaml"), "topic: orders.deploy\n")
+
+	repoScanner, err := NewRepoScannerWithConfig(Config{Include: []string{"src/**"}})
+	if err != nil {
+		t.Fatalf("NewRepoScannerWithConfig() error = %v", err)
+	}
+	result, err := repoScanner.Scan(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if _, ok := result.Topics["orders.deploy"]; ok || len(result.Topics) != 1 {
+		t.Fatalf("topics = %#v, want only src files", result.Topics)
+	}
+	if result.SkippedFiles[SkipReasonNotIncluded] != 1 {
+		t.Fatalf("SkippedFiles = %#v", result.SkippedFiles)
+	}
+
+	if _, err := NewRepoScannerWithConfig(Config{MaxFileSize: -1}); err == nil {
+		t.Fatalf("expected error for negative max file size")
+	}
+}
@@ -0,0 +1,543 @@
+package scanner
+
+import (
+	"strconv"
+	"strings"
+)
+
+// yamlKind identifies the shape of a parsed YAML node.
+type yamlKind int
+
+const (
+	yamlScalar yamlKind = iota
+	yamlMapping
+	yamlSequence
+)
+
+// yamlNode is a minimal, line-aware YAML tree. It covers the subset used by
+// configuration and manifest files (block mappings and sequences, flow
+// collections, quoted and block scalars, multi-document streams) and is
+// deliberately lenient: unsupported constructs degrade to scalars instead of
+// failing the scan.
+type yamlNode struct {
+	Kind   yamlKind
+	Value  string
+	Line   int
+	Keys   []string
+	Fields map[string]*yamlNode
+	Items  []*yamlNode
+}
+
+type yamlLine struct {
+	indent int
+	text   string
+	num    int
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAMLDocuments parses a (multi-document) YAML stream.
+func parseYAMLDocuments(content []byte) []*yamlNode {
+	raw := strings.Split(strings.TrimPrefix(string(content), "\uFEFF"), "\n")
+
+	docs := make([]*yamlNode, 0, 1)
+	current := make([]yamlLine, 0, len(raw))
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		p := &yamlParser{lines: current}
+		if node := p.parseBlock(current[0].indent); node != nil {
+			docs = append(docs, node)
+		}
+		current = make([]yamlLine, 0)
+	}
+
+	for i := 0; i < len(raw); i++ {
+		line := strings.TrimRight(raw[i], "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "---" || strings.HasPrefix(trimmed, "--- ") || trimmed == "..." {
+			flush()
+			if rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "---")); rest != "" && rest != "." && !strings.HasPrefix(trimmed, "...") {
+				current = append(current, yamlLine{indent: 0, text: rest, num: i + 1})
+			}
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "%") {
+			continue
+		}
+
+		text := strings.TrimSpace(stripYAMLComment(line))
+		if text == "" {
+			continue
+		}
+
+		// Block scalars: fold the indented body into the indicator line.
+		if indicator := blockScalarIndicator(text); indicator != "" {
+			indent := leadingIndent(line)
+			body := make([]string, 0)
+			j := i + 1
+			for ; j < len(raw); j++ {
+				next := strings.TrimRight(raw[j], "\r")
+				if strings.TrimSpace(next) == "" {
+					body = append(body, "")
+					continue
+				}
+				if leadingIndent(next) <= indent {
+					break
+				}
+				body = append(body, strings.TrimSpace(next))
+			}
+			value := strings.TrimRight(strings.Join(body, "\n"), "\n")
+			if strings.HasPrefix(indicator, ">") {
+				value = strings.Join(strings.Fields(value), " ")
+			}
+			prefix := strings.TrimSuffix(text, indicator)
+			current = append(current, yamlLine{indent: indent, text: prefix + strconv.Quote(value), num: i + 1})
+			i = j - 1
+			continue
+		}
+
+		current = append(current, yamlLine{indent: leadingIndent(line), text: text, num: i + 1})
+	}
+	flush()
+
+	return docs
+}
+
+func blockScalarIndicator(text string) string {
+	for _, indicator := range []string{"|-", "|+", "|", ">-", ">+", ">"} {
+		if !strings.HasSuffix(text, indicator) {
+			continue
+		}
+		prefix := strings.TrimSuffix(text, indicator)
+		if prefix == "" || strings.HasSuffix(prefix, ": ") || strings.HasSuffix(prefix, "- ") || prefix == "-" {
+			return indicator
+		}
+	}
+	return ""
+}
+
+func (p *yamlParser) peek() (yamlLine, bool) {
+	if p.pos >= len(p.lines) {
+		return yamlLine{}, false
+	}
+	return p.lines[p.pos], true
+}
+
+func (p *yamlParser) parseBlock(indent int) *yamlNode {
+	line, ok := p.peek()
+	if !ok || line.indent < indent {
+		return nil
+	}
+	if isYAMLSequenceItem(line.text) {
+		return p.parseSequence(line.indent)
+	}
+	if _, _, isPair := splitYAMLPair(line.text); isPair {
+		return p.parseMapping(line.indent)
+	}
+
+	p.pos++
+	return parseYAMLInline(line.text, line.num)
+}
+
+func (p *yamlParser) parseMapping(indent int) *yamlNode {
+	first, _ := p.peek()
+	node := &yamlNode{Kind: yamlMapping, Line: first.num, Fields: make(map[string]*yamlNode)}
+
+	for {
+		line, ok := p.peek()
+		if !ok || line.indent != indent || isYAMLSequenceItem(line.text) {
+			break
+		}
+		key, value, isPair := splitYAMLPair(line.text)
+		if !isPair {
+			break
+		}
+		p.pos++
+
+		var child *yamlNode
+		if value == "" {
+			next, hasNext := p.peek()
+			switch {
+			case hasNext && next.indent > indent:
+				child = p.parseBlock(next.indent)
+			case hasNext && next.indent == indent && isYAMLSequenceItem(next.text):
+				child = p.parseSequence(indent)
+			}
+			if child == nil {
+				child = &yamlNode{Kind: yamlScalar}
+			}
+			child.Line = line.num
+		} else {
+			child = parseYAMLInline(value, line.num)
+		}
+
+		if _, exists := node.Fields[key]; !exists {
+			node.Keys = append(node.Keys, key)
+		}
+		node.Fields[key] = child
+	}
+
+	return node
+}
+
+func (p *yamlParser) parseSequence(indent int) *yamlNode {
+	first, _ := p.peek()
+	node := &yamlNode{Kind: yamlSequence, Line: first.num}
+
+	for {
+		line, ok := p.peek()
+		if !ok || line.indent != indent || !isYAMLSequenceItem(line.text) {
+			break
+		}
+
+		rest := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		if rest == "" {
+			p.pos++
+			var child *yamlNode
+			if next, hasNext := p.peek(); hasNext && next.indent > indent {
+				child = p.parseBlock(next.indent)
+			}
+			if child == nil {
+				child = &yamlNode{Kind: yamlScalar}
+			}
+			child.Line = line.num
+			node.Items = append(node.Items, child)
+			continue
+		}
+
+		// "- key: value" starts a mapping whose keys align with "key".
+		offset := strings.Index(line.text, rest)
+		if _, _, isPair := splitYAMLPair(rest); isPair || isYAMLSequenceItem(rest) {
+			p.lines[p.pos] = yamlLine{indent: indent + offset, text: rest, num: line.num}
+			child := p.parseBlock(indent + offset)
+			if child != nil {
+				node.Items = append(node.Items, child)
+			}
+			continue
+		}
+
+		p.pos++
+		node.Items = append(node.Items, parseYAMLInline(rest, line.num))
+	}
+
+	return node
+}
+
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// splitYAMLPair splits "key: value" outside of quotes and flow collections.
+func splitYAMLPair(text string) (string, string, bool) {
+	if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		return "", "", false
+	}
+
+	inSingle := false
+	inDouble := false
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\\' && inDouble:
+			i++
+		case c == '\'' && !inDouble:
+			inSingle = !inSingle
+		case c == '"' && !inSingle:
+			inDouble = !inDouble
+		case c == ':' && !inSingle && !inDouble:
+			if i+1 < len(text) && text[i+1] != ' ' && text[i+1] != '\t' {
+				continue
+			}
+			key := unquoteYAMLScalar(strings.TrimSpace(text[:i]))
+			if key == "" {
+				return "", "", false
+			}
+			return key, strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+func parseYAMLInline(text string, line int) *yamlNode {
+	text = stripYAMLTag(strings.TrimSpace(text))
+	if strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		fp := &yamlFlowParser{text: text, line: line}
+		if node := fp.parseValue(); node != nil {
+			return node
+		}
+	}
+	return &yamlNode{Kind: yamlScalar, Value: unquoteYAMLScalar(text), Line: line}
+}
+
+func stripYAMLTag(text string) string {
+	for strings.HasPrefix(text, "!") || strings.HasPrefix(text, "&") {
+		_, rest, ok := strings.Cut(text, " ")
+		if !ok {
+			return ""
+		}
+		text = strings.TrimSpace(rest)
+	}
+	return text
+}
+
+func unquoteYAMLScalar(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		if parsed, err := strconv.Unquote(value); err == nil {
+			return parsed
+		}
+		return value[1 : len(value)-1]
+	}
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	if value == "~" || value == "null" {
+		return ""
+	}
+	return value
+}
+
+// stripYAMLComment removes a trailing " # comment" outside of quotes.
+func stripYAMLComment(line string) string {
+	inSingle := false
+	inDouble := false
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && inDouble:
+			i++
+		case c == '\'' && !inDouble:
+			inSingle = !inSingle
+		case c == '"' && !inSingle:
+			inDouble = !inDouble
+		case c == '#' && !inSingle && !inDouble:
+			if i == 0 || line[i-1] == ' ' || line[i-1] == '\t' {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+type yamlFlowParser struct {
+	text string
+	pos  int
+	line int
+}
+
+func (f *yamlFlowParser) skipSpace() {
+	for f.pos < len(f.text) && (f.text[f.pos] == ' ' || f.text[f.pos] == '\t') {
+		f.pos++
+	}
+}
+
+func (f *yamlFlowParser) parseValue() *yamlNode {
+	f.skipSpace()
+	if f.pos >= len(f.text) {
+		return nil
+	}
+	switch f.text[f.pos] {
+	case '[':
+		return f.parseSequence()
+	case '{':
+		return f.parseMapping()
+	default:
+		return &yamlNode{Kind: yamlScalar, Value: unquoteYAMLScalar(f.scalar()), Line: f.line}
+	}
+}
+
+// parseSequence reads a flow sequence. A "key: value" item is a
+// single-pair mapping, as in "include: [os: linux]". Malformed input ends
+// the sequence rather than looping without progress.
+func (f *yamlFlowParser) parseSequence() *yamlNode {
+	node := &yamlNode{Kind: yamlSequence, Line: f.line}
+	f.pos++
+	for {
+		f.skipSpace()
+		if f.pos >= len(f.text) {
+			return node
+		}
+		if f.text[f.pos] == ']' {
+			f.pos++
+			return node
+		}
+		if f.text[f.pos] == ',' {
+			f.pos++
+			continue
+		}
+		start := f.pos
+		item := f.parseValue()
+		if item == nil {
+			return node
+		}
+		f.skipSpace()
+		if item.Kind == yamlScalar && f.pos < len(f.text) && f.text[f.pos] == ':' {
+			f.pos++
+			value := &yamlNode{Kind: yamlScalar, Line: f.line}
+			if parsed := f.parseValue(); parsed != nil {
+				value = parsed
+			}
+			item = &yamlNode{
+				Kind:   yamlMapping,
+				Line:   f.line,
+				Keys:   []string{item.Value},
+				Fields: map[string]*yamlNode{item.Value: value},
+			}
+		}
+		if f.pos == start {
+			return node
+		}
+		node.Items = append(node.Items, item)
+	}
+}
+
+func (f *yamlFlowParser) parseMapping() *yamlNode {
+	node := &yamlNode{Kind: yamlMapping, Line: f.line, Fields: make(map[string]*yamlNode)}
+	f.pos++
+	for {
+		f.skipSpace()
+		if f.pos >= len(f.text) {
+			return node
+		}
+		if f.text[f.pos] == '}' {
+			f.pos++
+			return node
+		}
+		if f.text[f.pos] == ',' {
+			f.pos++
+			continue
+		}
+		start := f.pos
+		key := unquoteYAMLScalar(f.scalar())
+		f.skipSpace()
+		value := &yamlNode{Kind: yamlScalar, Line: f.line}
+		if f.pos < len(f.text) && f.text[f.pos] == ':' {
+			f.pos++
+			if parsed := f.parseValue(); parsed != nil {
+				value = parsed
+			}
+		}
+		if f.pos == start {
+			return node
+		}
+		if key == "" {
+			continue
+		}
+		if _, exists := node.Fields[key]; !exists {
+			node.Keys = append(node.Keys, key)
+		}
+		node.Fields[key] = value
+	}
+}
+
+// scalar reads a flow scalar up to the next unquoted separator.
+func (f *yamlFlowParser) scalar() string {
+	start := f.pos
+	if f.pos < len(f.text) && (f.text[f.pos] == '"' || f.text[f.pos] == '\'') {
+		quote := f.text[f.pos]
+		f.pos++
+		for f.pos < len(f.text) {
+			c := f.text[f.pos]
+			if c == '\\' && quote == '"' {
+				f.pos += 2
+				continue
+			}
+			f.pos++
+			if c == quote {
+				break
+			}
+		}
+		f.pos = min(f.pos, len(f.text))
+		return f.text[start:f.pos]
+	}
+	for f.pos < len(f.text) {
+		c := f.text[f.pos]
+		if c == ',' || c == ']' || c == '}' || (c == ':' && (f.pos+1 >= len(f.text) || f.text[f.pos+1] == ' ')) {
+			break
+		}
+		f.pos++
+	}
+	return strings.TrimSpace(f.text[start:f.pos])
+}
+
+// get returns the child node for key, or nil.
+func (n *yamlNode) get(key string) *yamlNode {
+	if n == nil || n.Kind != yamlMapping {
+		return nil
+	}
+	return n.Fields[key]
+}
+
+// lookup walks a path of mapping keys.
+func (n *yamlNode) lookup(path ...string) *yamlNode {
+	current := n
+	for _, key := range path {
+		current = current.get(key)
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+// scalar returns the scalar value of the node, or "".
+func (n *yamlNode) scalar() string {
+	if n == nil || n.Kind != yamlScalar {
+		return ""
+	}
+	return n.Value
+}
+
+// strings returns scalar items of a sequence, or the scalar itself.
+func (n *yamlNode) strings() []string {
+	if n == nil {
+		return nil
+	}
+	switch n.Kind {
+	case yamlScalar:
+		if n.Value == "" {
+			return nil
+		}
+		return []string{n.Value}
+	case yamlSequence:
+		out := make([]string, 0, len(n.Items))
+		for _, item := range n.Items {
+			if item.Kind == yamlScalar && item.Value != "" {
+				out = append(out, item.Value)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// flatten appends dotted key/value pairs for every scalar below the node,
+// using key[i] for sequence items (Spring relaxed-binding style).
+func (n *yamlNode) flatten(prefix string, out *[]propertyEntry) {
+	if n == nil {
+		return
+	}
+	switch n.Kind {
+	case yamlScalar:
+		if prefix != "" {
+			*out = append(*out, propertyEntry{Key: prefix, Value: n.Value, Line: n.Line})
+		}
+	case yamlMapping:
+		for _, key := range n.Keys {
+			child := key
+			if prefix != "" {
+				child = prefix + "." + key
+			}
+			n.Fields[key].flatten(child, out)
+		}
+	case yamlSequence:
+		for i, item := range n.Items {
+			item.flatten(prefix+"["+strconv.Itoa(i)+"]", out)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseYAMLDocuments(t *testing.T) {
+	content := []byte(`kind: Example # trailing comment
+metadata:
+  name: &anchor demo
+  labels: {team: core, tier: "1"}
+spec:
+  topics: [orders, 'payments']
+  items:
+    - name: first
+      value: 1
+    - plain
+  text: |
+    line one
+    line two
+---
+second: doc
+`)
+
+	docs := parseYAMLDocuments(content)
+	if len(docs) != 2 {
+		t.Fatalf("documents = %d, want 2", len(docs))
+	}
+
+	doc := docs[0]
+	if got := doc.get("kind").scalar(); got != "Example" {
+		t.Fatalf("kind = %q", got)
+	}
+	name := doc.lookup("metadata", "name")
+	if name.scalar() != "demo" || name.Line != 3 {
+		t.Fatalf("metadata.name = %q (line %d)", name.scalar(), name.Line)
+	}
+	if got := doc.lookup("metadata", "labels", "tier").scalar(); got != "1" {
+		t.Fatalf("labels.tier = %q", got)
+	}
+	if got := doc.lookup("spec", "topics").strings(); !reflect.DeepEqual(got, []string{"orders", "payments"}) {
+		t.Fatalf("spec.topics = %#v", got)
+	}
+
+	items := doc.lookup("spec", "items")
+	if items == nil || items.Kind != yamlSequence || len(items.Items) != 2 {
+		t.Fatalf("spec.items = %+v", items)
+	}
+	if items.Items[0].get("name").scalar() != "first" || items.Items[0].Line != 8 {
+		t.Fatalf("first item = %+v", items.Items[0])
+	}
+	if items.Items[1].scalar() != "plain" {
+		t.Fatalf("second item = %+v", items.Items[1])
+	}
+	if doc.lookup("spec", "text") == nil {
+		t.Fatalf("expected block scalar value")
+	}
+
+	if got := docs[1].get("second").scalar(); got != "doc" {
+		t.Fatalf("second document = %q", got)
+	}
+	if doc.lookup("missing", "path") != nil {
+		t.Fatalf("expected nil for missing path")
+	}
+}
+
+func TestParseYAMLFlowPairs(t *testing.T) {
+	docs := parseYAMLDocuments([]byte("topics: [a: b]\nmatrix:\n  include: [os: linux, {arch: arm64}, plain]\n"))
+	if len(docs) != 1 {
+		t.Fatalf("documents = %d, want 1", len(docs))
+	}
+
+	topics := docs[0].get("topics")
+	if topics == nil || topics.Kind != yamlSequence || len(topics.Items) != 1 || topics.Items[0].get("a").scalar() != "b" {
+		t.Fatalf("topics = %+v", topics)
+	}
+	include := docs[0].lookup("matrix", "include")
+	if include == nil || len(include.Items) != 3 {
+		t.Fatalf("matrix.include = %+v", include)
+	}
+	if include.Items[0].get("os").scalar() != "linux" || include.Items[1].get("arch").scalar() != "arm64" || include.Items[2].scalar() != "plain" {
+		t.Fatalf("matrix.include items = %+v, %+v, %+v", include.Items[0], include.Items[1], include.Items[2])
+	}
+
+	// Malformed flow collections must terminate.
+	for _, text := range []string{"[: x]", "{a: b]", "[x }", `["abc\`, "[a: [b: c}]"} {
+		parseYAMLDocuments([]byte("key: " + text + "\n"))
+	}
+}